@@ -0,0 +1,295 @@
+package dnspod
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// apiBackend is the API protocol used by the DomainsService and the RecordsService.
+//
+// The Client uses the legacy DNSPod API by default, served by dnsapi.cn,
+// or by api.dnspod.com when CommonParams.IsInternational is set.
+// WithTencentCloud switches to the Tencent Cloud API 3.0, and WithBackend to the in-memory FakeBackend.
+//
+// The other services send their methods through the backend if it implements methodCaller,
+// and return ErrUnsupported otherwise.
+type apiBackend interface {
+	ListDomains(ctx context.Context, opts DomainListOptions) (DomainPage, *Response, error)
+	GetDomain(ctx context.Context, domainID string) (Domain, *Response, error)
+	CreateDomain(ctx context.Context, domain Domain) (DomainCreateResp, *Response, error)
+	DeleteDomain(ctx context.Context, domainID string) (*Response, error)
+
+	// ListRecords returns a page of the records of a domain.
+	// It returns ErrEmptyResult when no record matches the options.
+	ListRecords(ctx context.Context, domainID string, opts RecordListOptions) (*DomainWithRecords, *Response, error)
+	GetRecord(ctx context.Context, domainID, recordID string) (Record, *Response, error)
+	CreateRecord(ctx context.Context, domainID string, record Record) (Record, *Response, error)
+	UpdateRecord(ctx context.Context, domainID, recordID string, record Record) (RecordModify, *Response, error)
+	DeleteRecord(ctx context.Context, domainID, recordID string) (*Response, error)
+}
+
+// methodCaller is implemented by the backends serving methods of the legacy API beyond the apiBackend interface,
+// sent by the other services of the Client (e.g. Record.Status, Record.Line or Monitor.Create).
+type methodCaller interface {
+	// callMethod sends a method of the legacy API with its form parameters, and decodes the data of the response into v.
+	// It returns an error wrapping ErrUnsupported for the methods it doesn't serve.
+	callMethod(ctx context.Context, method string, payload url.Values, v interface{}) (*Response, error)
+}
+
+// legacyBackend is the form-encoded DNSPod API served by dnsapi.cn.
+type legacyBackend struct {
+	client *Client
+}
+
+// callMethod implements methodCaller: all the methods are sent to the API.
+func (b *legacyBackend) callMethod(ctx context.Context, method string, payload url.Values, v interface{}) (*Response, error) {
+	return b.client.DoWithContext(ctx, http.MethodPost, method, payload, v)
+}
+
+// ListDomains implements apiBackend.
+func (b *legacyBackend) ListDomains(ctx context.Context, opts DomainListOptions) (DomainPage, *Response, error) {
+	payload := b.client.CommonParams.toPayLoad()
+	opts.setPayload(payload)
+
+	returnedDomains := domainListWrapper{}
+
+	res, err := b.client.post(ctx, methodDomainList, payload, &returnedDomains)
+	if err != nil {
+		return DomainPage{}, res, err
+	}
+
+	return DomainPage{Info: returnedDomains.Info, Domains: returnedDomains.Domains}, res, nil
+}
+
+// CreateDomain implements apiBackend.
+func (b *legacyBackend) CreateDomain(ctx context.Context, domainAttributes Domain) (DomainCreateResp, *Response, error) {
+	payload := b.client.CommonParams.toPayLoad()
+	payload.Set("domain", domainAttributes.Name)
+	payload.Set("group_id", domainAttributes.GroupID.String())
+	payload.Set("is_mark", domainAttributes.IsMark)
+
+	returnedDomain := domainCreateWrapper{}
+
+	res, err := b.client.post(ctx, methodDomainCreate, payload, &returnedDomain)
+	if err != nil {
+		return DomainCreateResp{}, res, err
+	}
+
+	return returnedDomain.Domain, res, nil
+}
+
+// GetDomain implements apiBackend.
+func (b *legacyBackend) GetDomain(ctx context.Context, domainId string) (Domain, *Response, error) {
+	payload := b.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainId)
+
+	returnedDomain := domainWrapper{}
+
+	res, err := b.client.post(ctx, methodDomainInfo, payload, &returnedDomain)
+	if err != nil {
+		return Domain{}, res, err
+	}
+
+	return returnedDomain.Domain, res, nil
+}
+
+// DeleteDomain implements apiBackend.
+func (b *legacyBackend) DeleteDomain(ctx context.Context, domainId string) (*Response, error) {
+	payload := b.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainId)
+
+	returnedDomain := domainWrapper{}
+
+	res, err := b.client.post(ctx, methodDomainRemove, payload, &returnedDomain)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// ListRecords implements apiBackend.
+func (b *legacyBackend) ListRecords(ctx context.Context, domainID string, opts RecordListOptions) (*DomainWithRecords, *Response, error) {
+	payload := b.client.CommonParams.toPayLoad()
+	payload.Set("domain_id", domainID)
+	opts.setPayload(payload)
+
+	wrappedRecords := &DomainWithRecords{}
+
+	res, err := b.client.post(ctx, methodRecordList, payload, wrappedRecords)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return wrappedRecords, res, nil
+}
+
+// CreateRecord implements apiBackend.
+func (b *legacyBackend) CreateRecord(ctx context.Context, domain string, recordAttributes Record) (Record, *Response, error) {
+	payload := b.client.CommonParams.toPayLoad()
+	payload.Add("domain_id", domain)
+
+	if recordAttributes.Name != "" {
+		payload.Add("sub_domain", recordAttributes.Name)
+	}
+
+	if recordAttributes.Type != "" {
+		payload.Add("record_type", string(recordAttributes.Type))
+	}
+
+	if recordAttributes.Line != "" {
+		payload.Add("record_line", recordAttributes.Line)
+	}
+
+	if recordAttributes.LineID != "" {
+		payload.Add("record_line_id", recordAttributes.LineID)
+	}
+
+	if recordAttributes.Value != "" {
+		payload.Add("value", recordAttributes.Value)
+	}
+
+	if recordAttributes.MX != "" {
+		payload.Add("mx", recordAttributes.MX)
+	}
+
+	if recordAttributes.TTL != "" {
+		payload.Add("ttl", recordAttributes.TTL)
+	}
+
+	if recordAttributes.Status != "" {
+		payload.Add("status", recordAttributes.Status)
+	}
+
+	if recordAttributes.Weight != nil {
+		payload.Add("weight", strconv.Itoa(*recordAttributes.Weight))
+	}
+
+	returnedRecord := recordWrapper{}
+
+	res, err := b.client.post(ctx, methodRecordCreate, payload, &returnedRecord)
+	if err != nil {
+		return Record{}, res, err
+	}
+
+	return returnedRecord.Record, res, nil
+}
+
+// GetRecord implements apiBackend.
+func (b *legacyBackend) GetRecord(ctx context.Context, domain, recordID string) (Record, *Response, error) {
+	payload := b.client.CommonParams.toPayLoad()
+	payload.Add("domain_id", domain)
+	payload.Add("record_id", recordID)
+
+	returnedRecord := recordWrapper{}
+
+	res, err := b.client.post(ctx, methodRecordInfo, payload, &returnedRecord)
+	if err != nil {
+		return Record{}, res, err
+	}
+
+	return returnedRecord.Record, res, nil
+}
+
+// UpdateRecord implements apiBackend.
+func (b *legacyBackend) UpdateRecord(ctx context.Context, domain, recordID string, recordAttributes Record) (RecordModify, *Response, error) {
+	payload := b.client.CommonParams.toPayLoad()
+	payload.Add("domain_id", domain)
+	payload.Add("record_id", recordID)
+
+	if recordAttributes.Name != "" {
+		payload.Add("sub_domain", recordAttributes.Name)
+	}
+
+	if recordAttributes.Type != "" {
+		payload.Add("record_type", string(recordAttributes.Type))
+	}
+
+	if recordAttributes.Line != "" {
+		payload.Add("record_line", recordAttributes.Line)
+	}
+
+	if recordAttributes.LineID != "" {
+		payload.Add("record_line_id", recordAttributes.LineID)
+	}
+
+	if recordAttributes.Value != "" {
+		payload.Add("value", recordAttributes.Value)
+	}
+
+	if recordAttributes.MX != "" {
+		payload.Add("mx", recordAttributes.MX)
+	}
+
+	if recordAttributes.TTL != "" {
+		payload.Add("ttl", recordAttributes.TTL)
+	}
+
+	if recordAttributes.Status != "" {
+		payload.Add("status", recordAttributes.Status)
+	}
+
+	if recordAttributes.Weight != nil {
+		payload.Add("weight", strconv.Itoa(*recordAttributes.Weight))
+	}
+
+	returnedRecord := recordModifyWrapper{}
+
+	res, err := b.client.post(ctx, methodRecordModify, payload, &returnedRecord)
+	if err != nil {
+		return RecordModify{}, res, err
+	}
+
+	return returnedRecord.Record, res, nil
+}
+
+// DeleteRecord implements apiBackend.
+func (b *legacyBackend) DeleteRecord(ctx context.Context, domainId, recordId string) (*Response, error) {
+	payload := b.client.CommonParams.toPayLoad()
+	payload.Add("domain_id", domainId)
+	payload.Add("record_id", recordId)
+
+	returnedRecord := recordWrapper{}
+
+	res, err := b.client.post(ctx, methodRecordRemove, payload, &returnedRecord)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// internationalBackend is the legacy DNSPod API served by api.dnspod.com.
+// It names the lines and the URL forwarding record types in English:
+// the Chinese names of the records sent are translated.
+type internationalBackend struct {
+	legacyBackend
+}
+
+// CreateRecord implements apiBackend.
+func (b *internationalBackend) CreateRecord(ctx context.Context, domain string, recordAttributes Record) (Record, *Response, error) {
+	return b.legacyBackend.CreateRecord(ctx, domain, internationalRecord(recordAttributes))
+}
+
+// UpdateRecord implements apiBackend.
+func (b *internationalBackend) UpdateRecord(ctx context.Context, domain, recordID string, recordAttributes Record) (RecordModify, *Response, error) {
+	return b.legacyBackend.UpdateRecord(ctx, domain, recordID, internationalRecord(recordAttributes))
+}
+
+// internationalRecord returns the record with the English names of its line and type.
+func internationalRecord(record Record) Record {
+	if english, ok := internationalLineNames[record.Line]; ok {
+		record.Line = english
+	}
+
+	switch record.Type {
+	case RecordTypeExplicitURL:
+		record.Type = RecordTypeURL
+	case RecordTypeImplicitURL:
+		record.Type = RecordTypeURL1
+	}
+
+	return record
+}
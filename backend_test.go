@@ -0,0 +1,39 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestInternationalBackend_CreateRecord(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	client.CommonParams.IsInternational = true
+	client.backend = &internationalBackend{legacyBackend{client: client}}
+
+	mux.HandleFunc("/Record.Create", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("record_line") != "default" || r.FormValue("record_type") != "URL" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"record":{"id":"26954449", "name":"www", "status":"enable"}}`)
+	})
+
+	_, _, err := client.Records.CreateWithContext(context.Background(), "44146112",
+		Record{Name: "www", Type: RecordTypeExplicitURL, Line: "默认", Value: "https://example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewClient_international(t *testing.T) {
+	client := NewClient(CommonParams{IsInternational: true})
+
+	if _, ok := client.backend.(*internationalBackend); !ok || client.BaseURL != defaultBaseURLInternational {
+		t.Errorf("got %T with %s, want the international API", client.backend, client.BaseURL)
+	}
+}
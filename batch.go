@@ -0,0 +1,234 @@
+package dnspod
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	methodBatchRecordCreate = "Batch.Record.Create"
+	methodBatchRecordModify = "Batch.Record.Modify"
+	methodBatchDomainCreate = "Batch.Domain.Create"
+	methodBatchDetail       = "Batch.Detail"
+)
+
+const defaultBatchPollInterval = 2 * time.Second
+
+// BatchRecord is a record created by a batch job.
+type BatchRecord struct {
+	Name  string     `json:"sub_domain"`
+	Type  RecordType `json:"record_type"`
+	Line  string     `json:"record_line,omitempty"`
+	Value string     `json:"value"`
+	TTL   string     `json:"ttl,omitempty"`
+	MX    string     `json:"mx,omitempty"`
+}
+
+// BatchChange is the field of the records modified by a batch job.
+type BatchChange string
+
+// Batch changes.
+const (
+	BatchChangeSubDomain BatchChange = "sub_domain"
+	BatchChangeType      BatchChange = "record_type"
+	BatchChangeLine      BatchChange = "area"
+	BatchChangeValue     BatchChange = "value"
+	BatchChangeMX        BatchChange = "mx"
+	BatchChangeTTL       BatchChange = "ttl"
+	BatchChangeStatus    BatchChange = "status"
+)
+
+// BatchRecordModify is the modification of records by a batch job.
+type BatchRecordModify struct {
+	RecordIDs []string
+	Change    BatchChange
+	ChangeTo  string
+
+	// Value and MX are required when the type of the records is changed.
+	Value string
+	MX    string
+}
+
+// BatchItem is the result of a batch job for a domain.
+type BatchItem struct {
+	ID            json.Number `json:"id,omitempty"`
+	Domain        string      `json:"domain,omitempty"`
+	DomainID      json.Number `json:"domain_id,omitempty"`
+	Status        string      `json:"status,omitempty"` // waiting, running, ok or error
+	Log           string      `json:"log,omitempty"`
+	Operation     string      `json:"operation,omitempty"`
+	RecordTotal   json.Number `json:"record_total,omitempty"`
+	RecordSuccess json.Number `json:"record_success,omitempty"`
+	RecordFailed  json.Number `json:"record_failed,omitempty"`
+}
+
+// Done reports whether the item has been processed.
+func (i BatchItem) Done() bool {
+	switch i.Status {
+	case "", "waiting", "running", "processing":
+		return false
+	default:
+		return true
+	}
+}
+
+// Failed reports whether the item, or some of its records, failed.
+func (i BatchItem) Failed() bool {
+	failed, _ := i.RecordFailed.Int64()
+	return i.Status == "error" || failed > 0
+}
+
+// BatchDetail is the progress of a batch job.
+type BatchDetail struct {
+	JobID string      `json:"job_id,omitempty"`
+	Items []BatchItem `json:"detail,omitempty"`
+}
+
+// Done reports whether all the items of the job have been processed.
+// A job without items is not done: its items may not be listed yet.
+func (d BatchDetail) Done() bool {
+	if len(d.Items) == 0 {
+		return false
+	}
+
+	for _, item := range d.Items {
+		if !item.Done() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Failed returns the items which failed.
+func (d BatchDetail) Failed() []BatchItem {
+	var failed []BatchItem
+	for _, item := range d.Items {
+		if item.Failed() {
+			failed = append(failed, item)
+		}
+	}
+
+	return failed
+}
+
+type batchJobWrapper struct {
+	JobID json.Number `json:"job_id"`
+}
+
+// BatchService handles communication with the batch related methods of the DNSPod API.
+// Batch jobs are processed asynchronously: use Wait to get their results.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/batch.html
+type BatchService struct {
+	client *Client
+}
+
+// CreateRecords submits a job creating the records in each domain, and returns the id of the job.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/batch.html#batch-record-create
+func (s *BatchService) CreateRecords(ctx context.Context, domainIDs []string, records []BatchRecord) (string, *Response, error) {
+	encodedRecords, err := json.Marshal(records)
+	if err != nil {
+		return "", nil, err
+	}
+
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("domain_id", strings.Join(domainIDs, ","))
+	payload.Set("records", string(encodedRecords))
+
+	return s.submit(ctx, methodBatchRecordCreate, payload)
+}
+
+// ModifyRecords submits a job modifying a field of the records, and returns the id of the job.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/batch.html#batch-record-modify
+func (s *BatchService) ModifyRecords(ctx context.Context, modify BatchRecordModify) (string, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("record_id", strings.Join(modify.RecordIDs, ","))
+	payload.Set("change", string(modify.Change))
+	payload.Set("change_to", modify.ChangeTo)
+
+	if modify.Value != "" {
+		payload.Set("value", modify.Value)
+	}
+
+	if modify.MX != "" {
+		payload.Set("mx", modify.MX)
+	}
+
+	return s.submit(ctx, methodBatchRecordModify, payload)
+}
+
+// CreateDomains submits a job creating the domains, and returns the id of the job.
+// If recordValue is not empty, default records pointing to it are created in each domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/batch.html#batch-domain-create
+func (s *BatchService) CreateDomains(ctx context.Context, domains []string, recordValue string) (string, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("domains", strings.Join(domains, ","))
+
+	if recordValue != "" {
+		payload.Set("record_value", recordValue)
+	}
+
+	return s.submit(ctx, methodBatchDomainCreate, payload)
+}
+
+// Detail fetches the progress of a job.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/batch.html#batch-detail
+func (s *BatchService) Detail(ctx context.Context, jobID string) (BatchDetail, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("job_id", jobID)
+
+	returnedDetail := BatchDetail{}
+
+	res, err := s.client.post(ctx, methodBatchDetail, payload, &returnedDetail)
+	if err != nil {
+		return BatchDetail{}, res, err
+	}
+
+	returnedDetail.JobID = jobID
+
+	return returnedDetail, res, nil
+}
+
+// Wait polls the progress of a job until all its items are processed, or until ctx is done.
+// The interval between two polls defaults to 2 seconds.
+func (s *BatchService) Wait(ctx context.Context, jobID string, interval time.Duration) (BatchDetail, *Response, error) {
+	if interval <= 0 {
+		interval = defaultBatchPollInterval
+	}
+
+	for {
+		detail, res, err := s.Detail(ctx, jobID)
+		if err != nil || detail.Done() {
+			return detail, res, err
+		}
+
+		err = sleep(ctx, interval)
+		if err != nil {
+			return detail, res, err
+		}
+	}
+}
+
+func (s *BatchService) submit(ctx context.Context, method string, payload url.Values) (string, *Response, error) {
+	returnedJob := batchJobWrapper{}
+
+	res, err := s.client.post(ctx, method, payload, &returnedJob)
+	if err != nil {
+		return "", res, err
+	}
+
+	return returnedJob.JobID.String(), res, nil
+}
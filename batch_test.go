@@ -0,0 +1,90 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBatchService_CreateRecords(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Batch.Record.Create", func(w http.ResponseWriter, r *http.Request) {
+		wantRecords := `[{"sub_domain":"www","record_type":"A","record_line":"默认","value":"1.1.1.1"}]`
+		if r.FormValue("domain_id") != "1,2" || r.FormValue("records") != wantRecords {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"job_id":12345}`)
+	})
+
+	records := []BatchRecord{{Name: "www", Type: "A", Line: "默认", Value: "1.1.1.1"}}
+
+	jobID, _, err := client.Batch.CreateRecords(context.Background(), []string{"1", "2"}, records)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if jobID != "12345" {
+		t.Errorf("got %v, want %v", jobID, "12345")
+	}
+}
+
+func TestBatchService_Wait(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/Batch.Detail", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		status := "ok"
+		if calls == 1 {
+			status = "waiting"
+		}
+
+		_, _ = fmt.Fprintf(w, `{
+			"status": {"code":"1","message":""},
+			"detail": [
+				{"domain": "example.com", "domain_id": 1, "status": "ok", "record_total": 1, "record_success": 1, "record_failed": 0},
+				{"domain": "example.net", "domain_id": 2, "status": %q, "record_total": 1, "record_success": 0, "record_failed": 1}
+			]}`, status)
+	})
+
+	detail, _, err := client.Batch.Wait(context.Background(), "12345", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 || !detail.Done() {
+		t.Errorf("got %d calls, want the job to be done after 2 calls", calls)
+	}
+
+	want := []BatchItem{{Domain: "example.net", DomainID: "2", Status: "ok", RecordTotal: "1", RecordSuccess: "0", RecordFailed: "1"}}
+	if failed := detail.Failed(); !reflect.DeepEqual(failed, want) {
+		t.Errorf("got %+v, want %+v", failed, want)
+	}
+}
+
+func TestBatchDetail_Done(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		detail BatchDetail
+		want   bool
+	}{
+		{desc: "no items", detail: BatchDetail{JobID: "12345"}, want: false},
+		{desc: "waiting", detail: BatchDetail{Items: []BatchItem{{Status: "ok"}, {Status: "waiting"}}}, want: false},
+		{desc: "processed", detail: BatchDetail{Items: []BatchItem{{Status: "ok"}, {Status: "ok"}}}, want: true},
+	}
+
+	for _, test := range testCases {
+		if got := test.detail.Done(); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.desc, got, test.want)
+		}
+	}
+}
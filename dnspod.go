@@ -4,6 +4,7 @@
 package dnspod
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,6 +23,9 @@ const (
 
 	defaultTimeout   = 5
 	defaultKeepAlive = 30
+
+	// maxErrorMessageLength is the length of the body kept as message of an ErrorResponse, if it isn't JSON.
+	maxErrorMessageLength = 512
 )
 
 // CommonParams is the commons parameters.
@@ -35,6 +39,9 @@ type CommonParams struct {
 	IsInternational bool
 	Timeout         int
 	KeepAlive       int
+
+	// RateLimit, if not nil, limits the rate of the calls sent by the client.
+	RateLimit *RateLimit
 }
 
 func (c CommonParams) toPayLoad() url.Values {
@@ -86,16 +93,35 @@ type Client struct {
 	// User agent used when communicating with the DNSPod API.
 	UserAgent string
 
+	// RetryPolicy used to retry failed calls.
+	// Calls are attempted only once if nil.
+	RetryPolicy *RetryPolicy
+
+	limiter *rateLimiter
+	logger  Logger
+
+	recordTypes recordTypeCache
+
+	// backend is the API protocol of the domains and the records.
+	backend apiBackend
+
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services used for talking to different parts of the DNSPod API.
-	Domains *DomainsService
-	Records *RecordsService
-	User    *UserService
+	Domains  *DomainsService
+	Records  *RecordsService
+	User     *UserService
+	Batch    *BatchService
+	Lines    *LinesService
+	Monitor  *MonitorService
+	Snapshot *SnapshotService
+
+	// LineResolver resolves line names to line ids, and back.
+	LineResolver *LineResolver
 }
 
-// NewClient returns a new DNSPod API client.
-func NewClient(params CommonParams) *Client {
+// NewClient returns a new DNSPod API client configured by the options.
+func NewClient(params CommonParams, opts ...Option) *Client {
 	timeout := defaultTimeout
 	if params.Timeout != 0 {
 		timeout = params.Timeout
@@ -106,14 +132,14 @@ func NewClient(params CommonParams) *Client {
 		keepalive = params.KeepAlive
 	}
 
-	httpClient := http.Client{
-		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				Timeout:   time.Duration(timeout) * time.Second,
-				KeepAlive: time.Duration(keepalive) * time.Second,
-			}).DialContext,
-		},
-	}
+	// keep the proxy, TLS and HTTP/2 settings of the default transport.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   time.Duration(timeout) * time.Second,
+		KeepAlive: time.Duration(keepalive) * time.Second,
+	}).DialContext
+
+	httpClient := http.Client{Transport: transport}
 
 	var baseURL string
 	if params.IsInternational {
@@ -124,10 +150,29 @@ func NewClient(params CommonParams) *Client {
 
 	client := &Client{HTTPClient: &httpClient, CommonParams: params, BaseURL: baseURL, UserAgent: defaultUserAgent}
 
+	if params.IsInternational {
+		client.backend = &internationalBackend{legacyBackend{client: client}}
+	} else {
+		client.backend = &legacyBackend{client: client}
+	}
+
+	if params.RateLimit != nil {
+		client.limiter = newRateLimiter(*params.RateLimit)
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
 	client.common.client = client
 	client.Domains = (*DomainsService)(&client.common)
 	client.Records = (*RecordsService)(&client.common)
 	client.User = (*UserService)(&client.common)
+	client.Batch = (*BatchService)(&client.common)
+	client.Lines = (*LinesService)(&client.common)
+	client.Monitor = (*MonitorService)(&client.common)
+	client.Snapshot = (*SnapshotService)(&client.common)
+	client.LineResolver = newLineResolver(client)
 
 	return client
 }
@@ -135,10 +180,19 @@ func NewClient(params CommonParams) *Client {
 // NewRequest creates an API request.
 // The path is expected to be a relative path and will be resolved
 // according to the BaseURL of the Client. Paths should always be specified without a preceding slash.
+//
+// NewRequest is a shortcut for NewRequestWithContext with context.Background.
 func (c *Client) NewRequest(method, path string, payload url.Values) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, path, payload)
+}
+
+// NewRequestWithContext creates an API request bound to ctx.
+// The context controls the entire lifetime of the request and its response:
+// obtaining a connection, sending the request, and reading the response.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, path string, payload url.Values) (*http.Request, error) {
 	uri := c.BaseURL + path
 
-	req, err := http.NewRequest(method, uri, strings.NewReader(payload.Encode()))
+	req, err := http.NewRequestWithContext(ctx, method, uri, strings.NewReader(payload.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -150,8 +204,13 @@ func (c *Client) NewRequest(method, path string, payload url.Values) (*http.Requ
 	return req, nil
 }
 
-func (c *Client) post(path string, payload url.Values, v interface{}) (*Response, error) {
-	return c.Do(http.MethodPost, path, payload, v)
+func (c *Client) post(ctx context.Context, path string, payload url.Values, v interface{}) (*Response, error) {
+	caller, ok := c.backend.(methodCaller)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, path)
+	}
+
+	return caller.callMethod(ctx, path, payload, v)
 }
 
 // Do sends an API request and returns the API response.
@@ -159,8 +218,76 @@ func (c *Client) post(path string, payload url.Values, v interface{}) (*Response
 // or returned as an error if an API error has occurred.
 // If v implements the io.Writer interface, the raw response body will be written to v,
 // without attempting to decode it.
+//
+// Do is a shortcut for DoWithContext with context.Background.
 func (c *Client) Do(method, path string, payload url.Values, v interface{}) (*Response, error) {
-	req, err := c.NewRequest(method, path, payload)
+	return c.DoWithContext(context.Background(), method, path, payload, v)
+}
+
+// DoWithContext sends an API request bound to ctx and returns the API response.
+// Cancelling ctx, or reaching its deadline, aborts the HTTP round trip.
+//
+// The status envelope of the API response is checked for every method:
+// a status code other than "1" is returned as an *APIError, along with the response.
+//
+// Failed calls are retried according to the RetryPolicy of the Client.
+func (c *Client) DoWithContext(ctx context.Context, method, path string, payload url.Values, v interface{}) (*Response, error) {
+	return c.retry(ctx, path, func() (*Response, error) {
+		return c.do(ctx, method, path, payload, v)
+	})
+}
+
+// retry performs the attempts of an API call until one succeeds, or until the RetryPolicy of the Client gives up.
+func (c *Client) retry(ctx context.Context, path string, do func() (*Response, error)) (*Response, error) {
+	for attempt := 1; ; attempt++ {
+		res, err := do()
+		if !c.RetryPolicy.retryable(path, attempt, err) {
+			return res, err
+		}
+
+		delay := c.RetryPolicy.backoff(attempt, res)
+
+		c.logf("dnspod: %s failed (attempt %d), retrying in %v: %v", path, attempt, delay, err)
+
+		if c.RetryPolicy.OnRetry != nil {
+			c.RetryPolicy.OnRetry(RetryEvent{Method: path, Attempt: attempt, Delay: delay, Err: err})
+		}
+
+		if errSleep := sleep(ctx, delay); errSleep != nil {
+			// report the failure of the call rather than the interruption of the wait.
+			return res, err
+		}
+	}
+}
+
+// wait blocks until the call is allowed by the rate limiter, if any.
+func (c *Client) wait(ctx context.Context, path string, payload url.Values) error {
+	if c.limiter == nil {
+		return nil
+	}
+
+	start := time.Now()
+
+	err := c.limiter.wait(ctx, path, payload)
+	if err != nil {
+		return err
+	}
+
+	if waited := time.Since(start); waited >= time.Millisecond {
+		c.logf("dnspod: %s throttled for %v by the rate limiter", path, waited)
+	}
+
+	return nil
+}
+
+// do performs a single attempt of an API call, once allowed by the rate limiter.
+func (c *Client) do(ctx context.Context, method, path string, payload url.Values, v interface{}) (*Response, error) {
+	err := c.wait(ctx, path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequestWithContext(ctx, method, path, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -177,17 +304,53 @@ func (c *Client) Do(method, path string, payload url.Values, v interface{}) (*Re
 		return response, err
 	}
 
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return response, err
+	}
+
+	err = checkStatus(path, body, response)
+	if err != nil {
+		return response, err
+	}
+
 	if v != nil {
 		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, res.Body)
+			_, err = w.Write(body)
 		} else {
-			err = json.NewDecoder(res.Body).Decode(v)
+			err = json.Unmarshal(body, v)
 		}
 	}
 
 	return response, err
 }
 
+// statusEnvelope is the part of the response shared by every method of the DNSPod API.
+type statusEnvelope struct {
+	Status *Status `json:"status"`
+}
+
+// checkStatus checks the status envelope of a response body, and returns an *APIError if the call failed.
+// Bodies that are not JSON objects, or without status, are left to the caller.
+func checkStatus(method string, body []byte, res *Response) error {
+	envelope := statusEnvelope{}
+	if json.Unmarshal(body, &envelope) != nil || envelope.Status == nil {
+		return nil
+	}
+
+	if envelope.Status.Code != "1" {
+		return newAPIError(method, *envelope.Status, res)
+	}
+
+	return nil
+}
+
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, v...)
+	}
+}
+
 // A Response represents an API response.
 type Response struct {
 	*http.Response
@@ -206,18 +369,44 @@ func (r *ErrorResponse) Error() string {
 		r.Response.StatusCode, r.Message)
 }
 
+// Unwrap returns the sentinel error matching the HTTP status code, or nil.
+func (r *ErrorResponse) Unwrap() error {
+	if r.Response == nil {
+		return nil
+	}
+
+	switch r.Response.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrLoginFailed
+	case http.StatusForbidden:
+		return ErrNoPermission
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
 // CheckResponse checks the API response for errors, and returns them if present.
 // A response is considered an error if the status code is different than 2xx. Specific requests
 // may have additional requirements, but this is sufficient in most of the cases.
+//
+// The error is always an *ErrorResponse: if the body isn't JSON (e.g. the HTML page of a gateway),
+// its beginning is used as message.
 func CheckResponse(r *http.Response) error {
 	if code := r.StatusCode; 200 <= code && code <= 299 {
 		return nil
 	}
 
 	errorResponse := &ErrorResponse{Response: r}
-	err := json.NewDecoder(r.Body).Decode(errorResponse)
-	if err != nil {
-		return err
+
+	body, _ := io.ReadAll(r.Body)
+	if json.Unmarshal(body, errorResponse) != nil {
+		if len(body) > maxErrorMessageLength {
+			body = body[:maxErrorMessageLength]
+		}
+
+		errorResponse.Message = strings.TrimSpace(string(body))
 	}
 
 	return errorResponse
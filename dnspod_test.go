@@ -1,6 +1,8 @@
 package dnspod
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -53,3 +55,20 @@ func TestNewRequest(t *testing.T) {
 		t.Errorf("makeRequest() User-Agent = %v, want %v", userAgent, client.UserAgent)
 	}
 }
+
+func TestDoWithContext_canceled(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Info", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.DoWithContext(ctx, http.MethodPost, "Domain.Info", nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+}
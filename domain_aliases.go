@@ -0,0 +1,81 @@
+package dnspod
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	methodDomainAliasList   = "Domain.Aliaslist"
+	methodDomainAliasAdd    = "Domain.Aliasadd"
+	methodDomainAliasRemove = "Domain.Aliasremove"
+)
+
+// DomainAlias is a domain bound to another domain, resolved with the same records.
+type DomainAlias struct {
+	ID       json.Number `json:"id,omitempty"`
+	Domain   string      `json:"domain,omitempty"`
+	PunyCode string      `json:"punycode,omitempty"`
+}
+
+type domainAliasListWrapper struct {
+	Aliases []DomainAlias `json:"alias"`
+}
+
+type domainAliasWrapper struct {
+	Alias DomainAlias `json:"alias"`
+}
+
+// ListAliases lists the aliases of a domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-aliaslist
+func (s *DomainsService) ListAliases(ctx context.Context, domainID string) ([]DomainAlias, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+
+	returnedAliases := domainAliasListWrapper{}
+
+	res, err := s.client.post(ctx, methodDomainAliasList, payload, &returnedAliases)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedAliases.Aliases, res, nil
+}
+
+// CreateAlias binds the alias to a domain.
+// The domain must be given by id, as the domain parameter holds the alias.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-aliasadd
+func (s *DomainsService) CreateAlias(ctx context.Context, domainID, alias string) (DomainAlias, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("domain_id", domainID)
+	payload.Set("domain", alias)
+
+	returnedAlias := domainAliasWrapper{}
+
+	res, err := s.client.post(ctx, methodDomainAliasAdd, payload, &returnedAlias)
+	if err != nil {
+		return DomainAlias{}, res, err
+	}
+
+	if returnedAlias.Alias.Domain == "" {
+		returnedAlias.Alias.Domain = alias
+	}
+
+	return returnedAlias.Alias, res, nil
+}
+
+// DeleteAlias unbinds an alias from a domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-aliasremove
+func (s *DomainsService) DeleteAlias(ctx context.Context, domainID, aliasID string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("alias_id", aliasID)
+
+	return s.client.post(ctx, methodDomainAliasRemove, payload, nil)
+}
@@ -0,0 +1,57 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDomainsService_ListAliases(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Aliaslist", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"alias": [
+				{"id": "16894439", "domain": "example.net"},
+				{"id": "16894440", "domain": "example.org"}
+			]}`)
+	})
+
+	aliases, _, err := client.Domains.ListAliases(context.Background(), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []DomainAlias{{ID: "16894439", Domain: "example.net"}, {ID: "16894440", Domain: "example.org"}}
+	if !reflect.DeepEqual(aliases, want) {
+		t.Errorf("got %+v, want %+v", aliases, want)
+	}
+}
+
+func TestDomainsService_CreateAlias(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Aliasadd", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain_id") != "2059079" || r.FormValue("domain") != "example.net" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"alias":{"id":"16894439","punycode":"example.net"}}`)
+	})
+
+	alias, _, err := client.Domains.CreateAlias(context.Background(), "2059079", "example.net")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := DomainAlias{ID: "16894439", Domain: "example.net", PunyCode: "example.net"}
+	if !reflect.DeepEqual(alias, want) {
+		t.Errorf("got %+v, want %+v", alias, want)
+	}
+}
@@ -0,0 +1,102 @@
+package dnspod
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	methodDomainGroupList   = "Domain.Grouplist"
+	methodDomainGroupAdd    = "Domain.Groupadd"
+	methodDomainGroupEdit   = "Domain.Groupedit"
+	methodDomainGroupRemove = "Domain.Groupremove"
+	methodDomainChangeGroup = "Domain.Changegroup"
+)
+
+// DomainGroup is a group of domains.
+type DomainGroup struct {
+	GroupID   json.Number `json:"group_id,omitempty"`
+	GroupName string      `json:"group_name,omitempty"`
+	GroupType string      `json:"group_type,omitempty"` // system or custom
+	Size      json.Number `json:"size,omitempty"`
+}
+
+type domainGroupListWrapper struct {
+	Groups []DomainGroup `json:"groups"`
+}
+
+type domainGroupCreateWrapper struct {
+	Groups struct {
+		ID json.Number `json:"id"`
+	} `json:"groups"`
+}
+
+// ListGroups lists the domain groups.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-grouplist
+func (s *DomainsService) ListGroups(ctx context.Context) ([]DomainGroup, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+
+	returnedGroups := domainGroupListWrapper{}
+
+	res, err := s.client.post(ctx, methodDomainGroupList, payload, &returnedGroups)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedGroups.Groups, res, nil
+}
+
+// CreateGroup creates a domain group.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-groupadd
+func (s *DomainsService) CreateGroup(ctx context.Context, groupName string) (DomainGroup, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("group_name", groupName)
+
+	returnedGroup := domainGroupCreateWrapper{}
+
+	res, err := s.client.post(ctx, methodDomainGroupAdd, payload, &returnedGroup)
+	if err != nil {
+		return DomainGroup{}, res, err
+	}
+
+	return DomainGroup{GroupID: returnedGroup.Groups.ID, GroupName: groupName}, res, nil
+}
+
+// UpdateGroup renames a domain group.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-groupedit
+func (s *DomainsService) UpdateGroup(ctx context.Context, groupID, groupName string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("group_id", groupID)
+	payload.Set("group_name", groupName)
+
+	return s.client.post(ctx, methodDomainGroupEdit, payload, nil)
+}
+
+// DeleteGroup deletes a domain group.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-groupremove
+func (s *DomainsService) DeleteGroup(ctx context.Context, groupID string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("group_id", groupID)
+
+	return s.client.post(ctx, methodDomainGroupRemove, payload, nil)
+}
+
+// ChangeGroup moves a domain to a group.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-changegroup
+func (s *DomainsService) ChangeGroup(ctx context.Context, domainID, groupID string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("group_id", groupID)
+
+	return s.client.post(ctx, methodDomainChangeGroup, payload, nil)
+}
@@ -0,0 +1,84 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDomainsService_ListGroups(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Grouplist", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "unsupported method", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"groups": [
+				{"group_id": 1, "group_name": "默认分组", "group_type": "system", "size": 25},
+				{"group_id": 1985, "group_name": "team-a", "group_type": "custom", "size": 3}
+			]}`)
+	})
+
+	groups, _, err := client.Domains.ListGroups(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []DomainGroup{
+		{GroupID: "1", GroupName: "默认分组", GroupType: "system", Size: "25"},
+		{GroupID: "1985", GroupName: "team-a", GroupType: "custom", Size: "3"},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("got %+v, want %+v", groups, want)
+	}
+}
+
+func TestDomainsService_CreateGroup(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Groupadd", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("group_name") != "team-a" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"groups":{"id":1985}}`)
+	})
+
+	group, _, err := client.Domains.CreateGroup(context.Background(), "team-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := DomainGroup{GroupID: "1985", GroupName: "team-a"}
+	if !reflect.DeepEqual(group, want) {
+		t.Errorf("got %+v, want %+v", group, want)
+	}
+}
+
+func TestDomainsService_ChangeGroup(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Changegroup", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain_id") != "2059079" || r.FormValue("group_id") != "1985" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
+	})
+
+	_, err := client.Domains.ChangeGroup(context.Background(), "2059079", "1985")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,101 @@
+package dnspod
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const methodDomainLog = "Domain.Log"
+
+// logLocation is the time zone of the DNSPod logs (China Standard Time).
+var logLocation = time.FixedZone("CST", 8*60*60)
+
+// logLineRegexp matches the log lines, e.g.:
+// "2012-09-05 10:55:34: (127.0.0.1) user@example.com 添加记录 www A 默认 1.1.1.1 600".
+var logLineRegexp = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}):?\s+\(([^)]*)\)\s+(\S+)\s+(.*)$`)
+
+// DomainLogOptions is the pagination of the domain log.
+type DomainLogOptions struct {
+	Offset int
+
+	// Length is the number of entries to fetch.
+	// Defaults to the API default (500).
+	Length int
+}
+
+// DomainLogEntry is an entry of the operation log of a domain.
+type DomainLogEntry struct {
+	Time     time.Time
+	IP       string
+	Operator string
+	Action   string // first word of the message, e.g. "添加记录"
+	Message  string
+
+	// Raw is the log line as returned by the API.
+	// Only Raw and Message are set if the line can't be parsed.
+	Raw string
+}
+
+type domainLogWrapper struct {
+	Log []string `json:"log"`
+}
+
+// Log fetches the operation log of a domain, most recent entries first.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-log
+func (s *DomainsService) Log(ctx context.Context, domainID string, opts DomainLogOptions) ([]DomainLogEntry, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+
+	if opts.Offset > 0 {
+		payload.Set("offset", strconv.Itoa(opts.Offset))
+	}
+
+	if opts.Length > 0 {
+		payload.Set("length", strconv.Itoa(opts.Length))
+	}
+
+	returnedLog := domainLogWrapper{}
+
+	res, err := s.client.post(ctx, methodDomainLog, payload, &returnedLog)
+	if err != nil {
+		return nil, res, err
+	}
+
+	entries := make([]DomainLogEntry, 0, len(returnedLog.Log))
+	for _, line := range returnedLog.Log {
+		entries = append(entries, parseLogLine(line))
+	}
+
+	return entries, res, nil
+}
+
+// parseLogLine parses a log line returned by the API.
+func parseLogLine(line string) DomainLogEntry {
+	entry := DomainLogEntry{Message: line, Raw: line}
+
+	parts := logLineRegexp.FindStringSubmatch(line)
+	if parts == nil {
+		return entry
+	}
+
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", parts[1], logLocation)
+	if err != nil {
+		return entry
+	}
+
+	entry.Time = t
+	entry.IP = parts[2]
+	entry.Operator = parts[3]
+	entry.Message = parts[4]
+
+	if fields := strings.Fields(parts[4]); len(fields) > 0 {
+		entry.Action = fields[0]
+	}
+
+	return entry
+}
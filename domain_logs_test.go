@@ -0,0 +1,65 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDomainsService_Log(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Log", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("offset") != "10" || r.FormValue("length") != "2" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"log": [
+				"2012-09-05 10:55:34: (127.0.0.1) user@example.com 添加记录 www A 默认 1.1.1.1 600",
+				"unexpected line"
+			]}`)
+	})
+
+	entries, _, err := client.Domains.Log(context.Background(), "2059079", DomainLogOptions{Offset: 10, Length: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []DomainLogEntry{
+		{
+			Time:     time.Date(2012, time.September, 5, 10, 55, 34, 0, logLocation),
+			IP:       "127.0.0.1",
+			Operator: "user@example.com",
+			Action:   "添加记录",
+			Message:  "添加记录 www A 默认 1.1.1.1 600",
+			Raw:      "2012-09-05 10:55:34: (127.0.0.1) user@example.com 添加记录 www A 默认 1.1.1.1 600",
+		},
+		{Message: "unexpected line", Raw: "unexpected line"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("got %+v, want %+v", entries, want)
+	}
+}
+
+func Test_parseLogLine_emptyMessage(t *testing.T) {
+	line := "2012-09-05 10:55:34: (127.0.0.1) user@example.com "
+
+	got := parseLogLine(line)
+
+	want := DomainLogEntry{
+		Time:     time.Date(2012, time.September, 5, 10, 55, 34, 0, logLocation),
+		IP:       "127.0.0.1",
+		Operator: "user@example.com",
+		Raw:      line,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
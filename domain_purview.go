@@ -0,0 +1,216 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+const methodDomainPurview = "Domain.Purview"
+
+// DomainPurview is a permission, or a limit, of the plan of a domain.
+type DomainPurview struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+// String returns the value of the purview.
+func (p DomainPurview) String() string {
+	switch v := p.Value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type domainPurviewWrapper struct {
+	Purview []DomainPurview `json:"purview"`
+}
+
+// DomainCapabilities describes what the plan of a domain allows.
+// Zero values mean the limit is unknown.
+type DomainCapabilities struct {
+	Grade         string
+	MinTTL        int
+	MaxRecords    int
+	LoadBalancing int // number of records allowed for the same sub domain, type and line
+	RecordTypes   []RecordType
+	URLForward    bool
+	Lines         []string
+}
+
+// validGrades are the grades of the DNSPod plans.
+var validGrades = []string{
+	"D_Free", "D_Plus", "D_Extra", "D_Expert", "D_Ultra",
+	"DP_Free", "DP_Plus", "DP_Extra", "DP_Expert", "DP_Ultra",
+	"DPG_Free", "DPG_Plus", "DPG_Extra", "DPG_Expert", "DPG_Ultra",
+}
+
+// Names of the purviews returned by Domain.Purview which are used by Capabilities.
+// The other purviews are ignored.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-purview
+const (
+	purviewMinTTL        = "记录TTL最低"
+	purviewLoadBalancing = "A记录负载均衡数量"
+	purviewURLForward    = "URL转发"
+	purviewMaxRecords    = "记录数量"
+)
+
+// validGrade reports whether the grade is a known DNSPod grade.
+func validGrade(grade string) bool {
+	return containsString(validGrades, grade)
+}
+
+// Purview fetches the permissions and limits of the plan of a domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-purview
+func (s *DomainsService) Purview(ctx context.Context, domainID string) ([]DomainPurview, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+
+	returnedPurview := domainPurviewWrapper{}
+
+	res, err := s.client.post(ctx, methodDomainPurview, payload, &returnedPurview)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedPurview.Purview, res, nil
+}
+
+// Capabilities returns what the plan of a domain allows: its purview, its record types and its lines.
+// URL forwarding is allowed if the purview doesn't say otherwise and the record types include a URL type.
+// The domain must have its ID, Name and Grade set, e.g. as returned by Get.
+func (s *DomainsService) Capabilities(ctx context.Context, domain Domain) (DomainCapabilities, *Response, error) {
+	if !validGrade(domain.Grade) {
+		return DomainCapabilities{}, nil, fmt.Errorf("invalid grade of domain: %s", domain.Grade)
+	}
+
+	capabilities := DomainCapabilities{URLForward: true}
+	capabilities.Grade = domain.Grade
+
+	purview, res, err := s.Purview(ctx, domain.ID.String())
+	if err != nil {
+		return DomainCapabilities{}, res, err
+	}
+
+	known := false
+	for _, p := range purview {
+		known = capabilities.apply(p) || known
+	}
+
+	if !known {
+		return DomainCapabilities{}, res, fmt.Errorf("no known purview for the domain %s", domain.Name)
+	}
+
+	capabilities.RecordTypes, res, err = s.client.Records.Types(ctx, domain.Grade)
+	if err != nil {
+		return DomainCapabilities{}, res, err
+	}
+
+	capabilities.URLForward = capabilities.URLForward && hasURLRecordType(capabilities.RecordTypes)
+
+	lines, res, err := s.GetLinesWithContext(ctx, domain.Name, domain.Grade)
+	if err != nil {
+		return DomainCapabilities{}, res, err
+	}
+
+	for _, line := range lines {
+		capabilities.Lines = append(capabilities.Lines, line.LineName)
+	}
+
+	return capabilities, res, nil
+}
+
+// apply overrides the capabilities with a purview, recognized by its exact name.
+// It reports whether the name is known.
+func (c *DomainCapabilities) apply(p DomainPurview) bool {
+	value := p.String()
+
+	switch p.Name {
+	case purviewMinTTL:
+		if v, err := strconv.Atoi(value); err == nil {
+			c.MinTTL = v
+		}
+	case purviewLoadBalancing:
+		if v, err := strconv.Atoi(value); err == nil {
+			c.LoadBalancing = v
+		}
+	case purviewURLForward:
+		c.URLForward = value != "no" && value != "0" && value != "false"
+	case purviewMaxRecords:
+		if v, err := strconv.Atoi(value); err == nil {
+			c.MaxRecords = v
+		}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// ValidateRecord checks a record against the capabilities, before submitting it to the API.
+func (c DomainCapabilities) ValidateRecord(record Record) error {
+	if record.TTL != "" && c.MinTTL > 0 {
+		ttl, err := strconv.Atoi(record.TTL)
+		if err != nil {
+			return fmt.Errorf("invalid TTL %q: %w", record.TTL, err)
+		}
+
+		if ttl < c.MinTTL {
+			return fmt.Errorf("TTL %d is lower than the minimum TTL of the grade %s: %d", ttl, c.Grade, c.MinTTL)
+		}
+	}
+
+	if record.Type != "" && len(c.RecordTypes) > 0 && !containsRecordType(c.RecordTypes, record.Type) {
+		return fmt.Errorf("record type %s is not allowed by the grade %s", record.Type, c.Grade)
+	}
+
+	if record.Type.IsURL() && !c.URLForward {
+		return fmt.Errorf("URL forwarding is not allowed by the grade %s", c.Grade)
+	}
+
+	if record.Line != "" && len(c.Lines) > 0 && !c.allowsLine(record.Line) {
+		return fmt.Errorf("line %s is not allowed by the grade %s", record.Line, c.Grade)
+	}
+
+	return nil
+}
+
+// allowsLine reports whether a line is allowed, the same way Create resolves it: aliases like "default" are accepted.
+func (c DomainCapabilities) allowsLine(name string) bool {
+	table := newLineTable(nil)
+	for _, line := range c.Lines {
+		table.add(Line{LineName: line})
+	}
+
+	_, ok := table.id(name)
+
+	return ok
+}
+
+func hasURLRecordType(types []RecordType) bool {
+	for _, t := range types {
+		if t.IsURL() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsRecordType(types []RecordType, t RecordType) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDomainsService_Capabilities(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Purview", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"purview": [
+				{"name": "记录TTL最低", "value": 300},
+				{"name": "A记录负载均衡数量", "value": "4"},
+				{"name": "URL转发", "value": "no"},
+				{"name": "记录数量", "value": 1000}
+			]}`)
+	})
+
+	mux.HandleFunc("/Record.Type", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"types":["A","CNAME","MX","TXT"]}`)
+	})
+
+	mux.HandleFunc("/Record.Line", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"line_ids":{"默认":0,"电信":"10=0"}}`)
+	})
+
+	domain := Domain{ID: "2059079", Name: "example.com", Grade: "DP_Free"}
+
+	capabilities, _, err := client.Domains.Capabilities(context.Background(), domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := DomainCapabilities{
+		Grade:         "DP_Free",
+		MinTTL:        300,
+		MaxRecords:    1000,
+		LoadBalancing: 4,
+		RecordTypes:   []RecordType{RecordTypeA, RecordTypeCNAME, RecordTypeMX, RecordTypeTXT},
+		URLForward:    false,
+		Lines:         []string{"默认", "电信"},
+	}
+	if !reflect.DeepEqual(capabilities, want) {
+		t.Errorf("got %+v, want %+v", capabilities, want)
+	}
+}
+
+func TestDomainsService_Capabilities_unknownPurview(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Purview", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"purview": [
+				{"name": "Minimum TTL", "value": 300},
+				{"name": "Records", "value": 1000}
+			]}`)
+	})
+
+	_, _, err := client.Domains.Capabilities(context.Background(), Domain{ID: "1", Name: "example.com", Grade: "DP_Free"})
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestDomainCapabilities_ValidateRecord(t *testing.T) {
+	capabilities := DomainCapabilities{
+		Grade:       "DP_Free",
+		MinTTL:      600,
+		RecordTypes: []RecordType{RecordTypeA, RecordTypeURL},
+		Lines:       []string{"默认", "电信"},
+	}
+
+	testCases := []struct {
+		desc   string
+		record Record
+		valid  bool
+	}{
+		{desc: "valid", record: Record{Type: "A", TTL: "600", Line: "默认"}, valid: true},
+		{desc: "line alias", record: Record{Type: "A", Line: "default"}, valid: true},
+		{desc: "TTL too low", record: Record{Type: "A", TTL: "60"}},
+		{desc: "unknown type", record: Record{Type: "AA"}},
+		{desc: "URL forwarding", record: Record{Type: "URL"}},
+		{desc: "unknown line", record: Record{Type: "A", Line: "移动"}},
+	}
+
+	for _, test := range testCases {
+		err := capabilities.ValidateRecord(test.record)
+		if (err == nil) != test.valid {
+			t.Errorf("%s: got %v", test.desc, err)
+		}
+	}
+}
+
+func TestDomainCapabilities_apply(t *testing.T) {
+	capabilities := DomainCapabilities{URLForward: true}
+
+	for _, p := range []DomainPurview{
+		{Name: "记录TTL最低", Value: float64(600)},
+		{Name: "URL转发数量", Value: float64(0)},
+		{Name: "搜索引擎推送 TTL", Value: float64(1)},
+		{Name: "records", Value: float64(10)},
+	} {
+		if known := capabilities.apply(p); known != (p.Name == "记录TTL最低") {
+			t.Errorf("%s: got known %t", p.Name, known)
+		}
+	}
+
+	want := DomainCapabilities{MinTTL: 600, URLForward: true}
+	if !reflect.DeepEqual(capabilities, want) {
+		t.Errorf("got %+v, want %+v", capabilities, want)
+	}
+}
@@ -0,0 +1,111 @@
+package dnspod
+
+import "context"
+
+const (
+	methodDomainShare       = "Domain.Share"
+	methodDomainShareList   = "Domain.Sharelist"
+	methodDomainShareModify = "Domain.Sharemodify"
+	methodDomainShareRemove = "Domain.Shareremove"
+)
+
+// DomainShareMode is the access mode of a shared domain.
+type DomainShareMode string
+
+// Domain share modes.
+const (
+	DomainShareReadOnly  DomainShareMode = "r"
+	DomainShareReadWrite DomainShareMode = "rw"
+)
+
+// DomainShare is the share of a domain with another DNSPod account.
+type DomainShare struct {
+	// ShareTo is the email of the account the domain is shared with.
+	ShareTo string          `json:"share_to,omitempty"`
+	Mode    DomainShareMode `json:"mode,omitempty"`
+	Status  string          `json:"status,omitempty"`
+
+	// SubDomain restricts the share to a sub domain (e.g. "www").
+	// The whole domain is shared if empty.
+	SubDomain string `json:"sub_domain,omitempty"`
+}
+
+type domainShareListWrapper struct {
+	Shares []DomainShare `json:"share"`
+	Owner  string        `json:"owner"`
+}
+
+// Share shares a domain, or one of its sub domains, with another account.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-share
+func (s *DomainsService) Share(ctx context.Context, domainID string, share DomainShare) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("email", share.ShareTo)
+
+	if share.Mode != "" {
+		payload.Set("mode", string(share.Mode))
+	}
+
+	if share.SubDomain != "" {
+		payload.Set("sub_domain", share.SubDomain)
+	}
+
+	return s.client.post(ctx, methodDomainShare, payload, nil)
+}
+
+// ListShares lists the shares of a domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-sharelist
+func (s *DomainsService) ListShares(ctx context.Context, domainID string) ([]DomainShare, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+
+	returnedShares := domainShareListWrapper{}
+
+	res, err := s.client.post(ctx, methodDomainShareList, payload, &returnedShares)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedShares.Shares, res, nil
+}
+
+// UpdateShare changes the mode, or the sub domain, of a share.
+// oldSubDomain is the sub domain currently shared, empty for the whole domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-sharemodify
+func (s *DomainsService) UpdateShare(ctx context.Context, domainID, oldSubDomain string, share DomainShare) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("email", share.ShareTo)
+
+	if share.Mode != "" {
+		payload.Set("mode", string(share.Mode))
+	}
+
+	if oldSubDomain != "" {
+		payload.Set("old_sub_domain", oldSubDomain)
+	}
+
+	if share.SubDomain != "" {
+		payload.Set("new_sub_domain", share.SubDomain)
+	}
+
+	return s.client.post(ctx, methodDomainShareModify, payload, nil)
+}
+
+// DeleteShare stops sharing a domain with an account.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-shareremove
+func (s *DomainsService) DeleteShare(ctx context.Context, domainID, email string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("email", email)
+
+	return s.client.post(ctx, methodDomainShareRemove, payload, nil)
+}
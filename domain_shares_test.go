@@ -0,0 +1,58 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDomainsService_Share(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Share", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("email") != "ops@example.com" || r.FormValue("mode") != "r" || r.FormValue("sub_domain") != "www" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
+	})
+
+	share := DomainShare{ShareTo: "ops@example.com", Mode: DomainShareReadOnly, SubDomain: "www"}
+
+	_, err := client.Domains.Share(context.Background(), "2059079", share)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDomainsService_ListShares(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Sharelist", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"share": [
+				{"share_to": "ops@example.com", "mode": "rw", "status": "enabled"},
+				{"share_to": "dev@example.com", "mode": "r", "status": "pending", "sub_domain": "www"}
+			],
+			"owner": "owner@example.com"}`)
+	})
+
+	shares, _, err := client.Domains.ListShares(context.Background(), "2059079")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []DomainShare{
+		{ShareTo: "ops@example.com", Mode: DomainShareReadWrite, Status: "enabled"},
+		{ShareTo: "dev@example.com", Mode: DomainShareReadOnly, Status: "pending", SubDomain: "www"},
+	}
+	if !reflect.DeepEqual(shares, want) {
+		t.Errorf("got %+v, want %+v", shares, want)
+	}
+}
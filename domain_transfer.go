@@ -0,0 +1,157 @@
+package dnspod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	methodDomainTransfer        = "Domain.Transfer"
+	methodDomainAcquire         = "Domain.Acquire"
+	methodDomainAcquireSend     = "Domain.Acquiresend"
+	methodDomainAcquireValidate = "Domain.Acquirevalidate"
+)
+
+type domainAcquireWrapper struct {
+	Emails []string `json:"emails"`
+}
+
+// DomainAcquireHandler drives the validation steps of CreateOrAcquire.
+type DomainAcquireHandler struct {
+	// SelectEmail chooses the email the verification code is sent to, among the ones offered by DNSPod.
+	// Defaults to the first email.
+	SelectEmail func(emails []string) (string, error)
+
+	// Code returns the verification code received by email, e.g. by prompting an operator.
+	Code func(ctx context.Context, email string) (string, error)
+}
+
+// DomainAcquisition is the outcome of CreateOrAcquire.
+type DomainAcquisition struct {
+	// Created is true if the domain was created in the account.
+	Created bool
+	Domain  DomainCreateResp
+
+	// Acquired is true if the domain was reclaimed from another account.
+	Acquired bool
+	Email    string // email the verification code was sent to
+}
+
+// Transfer moves a domain to another account, identified by its email.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-transfer
+func (s *DomainsService) Transfer(ctx context.Context, domainID, email string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("email", email)
+
+	return s.client.post(ctx, methodDomainTransfer, payload, nil)
+}
+
+// Acquire starts reclaiming a domain added by another account,
+// and returns the emails the verification code can be sent to.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-acquire
+func (s *DomainsService) Acquire(ctx context.Context, domain string) ([]string, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("domain", domain)
+
+	returnedEmails := domainAcquireWrapper{}
+
+	res, err := s.client.post(ctx, methodDomainAcquire, payload, &returnedEmails)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedEmails.Emails, res, nil
+}
+
+// AcquireSend sends the verification code of the reclaiming of a domain to one of the emails returned by Acquire.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-acquiresend
+func (s *DomainsService) AcquireSend(ctx context.Context, domain, email string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("domain", domain)
+	payload.Set("email", email)
+
+	return s.client.post(ctx, methodDomainAcquireSend, payload, nil)
+}
+
+// AcquireValidate completes the reclaiming of a domain with the verification code sent by AcquireSend.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-acquirevalidate
+func (s *DomainsService) AcquireValidate(ctx context.Context, domain, code string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("domain", domain)
+	payload.Set("code", code)
+
+	return s.client.post(ctx, methodDomainAcquireValidate, payload, nil)
+}
+
+// CreateOrAcquire creates a domain or, if it was already added by another account (ErrDomainTaken), reclaims it:
+// the verification code is sent to the email chosen by the handler, and validated with the code it returns.
+// Any other error, including ErrDomainExists for a domain already in the account, is returned unchanged.
+// Once reclaimed, the domain is fetched to fill the outcome, without its name servers.
+func (s *DomainsService) CreateOrAcquire(ctx context.Context, domainAttributes Domain, handler DomainAcquireHandler) (DomainAcquisition, *Response, error) {
+	created, res, err := s.CreateWithContext(ctx, domainAttributes)
+	if err == nil {
+		return DomainAcquisition{Created: true, Domain: created}, res, nil
+	}
+
+	if !errors.Is(err, ErrDomainTaken) {
+		return DomainAcquisition{}, res, err
+	}
+
+	if handler.Code == nil {
+		return DomainAcquisition{}, res, fmt.Errorf("%w: no handler to validate the acquisition", err)
+	}
+
+	emails, res, err := s.Acquire(ctx, domainAttributes.Name)
+	if err != nil {
+		return DomainAcquisition{}, res, err
+	}
+
+	var email string
+	switch {
+	case handler.SelectEmail != nil:
+		email, err = handler.SelectEmail(emails)
+		if err != nil {
+			return DomainAcquisition{}, res, err
+		}
+	case len(emails) > 0:
+		email = emails[0]
+	default:
+		return DomainAcquisition{}, res, fmt.Errorf("no email available to acquire %s", domainAttributes.Name)
+	}
+
+	res, err = s.AcquireSend(ctx, domainAttributes.Name, email)
+	if err != nil {
+		return DomainAcquisition{}, res, err
+	}
+
+	code, err := handler.Code(ctx, email)
+	if err != nil {
+		return DomainAcquisition{Email: email}, res, err
+	}
+
+	res, err = s.AcquireValidate(ctx, domainAttributes.Name, code)
+	if err != nil {
+		return DomainAcquisition{Email: email}, res, err
+	}
+
+	acquisition := DomainAcquisition{Acquired: true, Email: email}
+
+	domain, res, err := s.GetWithContext(ctx, domainAttributes.Name)
+	if err != nil {
+		return acquisition, res, err
+	}
+
+	acquisition.Domain = DomainCreateResp{Id: domain.ID.String(), Punycode: domain.PunyCode, Domain: domain.Name}
+
+	return acquisition, res, nil
+}
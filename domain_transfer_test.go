@@ -0,0 +1,123 @@
+package dnspod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDomainsService_CreateOrAcquire(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Create", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"12","message":"Domain already exists and you have no permission"}}`)
+	})
+
+	mux.HandleFunc("/Domain.Acquire", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"emails":["a***@example.com","b***@example.com"]}`)
+	})
+
+	mux.HandleFunc("/Domain.Acquiresend", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("email") != "b***@example.com" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
+	})
+
+	mux.HandleFunc("/Domain.Acquirevalidate", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain") != "example.com" || r.FormValue("code") != "123456" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
+	})
+
+	mux.HandleFunc("/Domain.Info", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain") != "example.com" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"domain":{"id":"2238269","name":"example.com","punycode":"example.com"}}`)
+	})
+
+	handler := DomainAcquireHandler{
+		SelectEmail: func(emails []string) (string, error) {
+			return emails[1], nil
+		},
+		Code: func(ctx context.Context, email string) (string, error) {
+			return "123456", nil
+		},
+	}
+
+	acquisition, _, err := client.Domains.CreateOrAcquire(context.Background(), Domain{Name: "example.com"}, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := DomainAcquisition{
+		Domain:   DomainCreateResp{Id: "2238269", Punycode: "example.com", Domain: "example.com"},
+		Acquired: true,
+		Email:    "b***@example.com",
+	}
+	if !reflect.DeepEqual(acquisition, want) {
+		t.Errorf("got %+v, want %+v", acquisition, want)
+	}
+}
+
+func TestDomainsService_CreateOrAcquire_created(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Create", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"domain":{"id":"1","punycode":"example.com","domain":"example.com"}}`)
+	})
+
+	mux.HandleFunc("/Domain.Acquire", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("a created domain should not be acquired")
+	})
+
+	acquisition, _, err := client.Domains.CreateOrAcquire(context.Background(), Domain{Name: "example.com"}, DomainAcquireHandler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !acquisition.Created || acquisition.Domain.Id != "1" {
+		t.Errorf("got %+v, want created domain 1", acquisition)
+	}
+}
+
+func TestDomainsService_CreateOrAcquire_exists(t *testing.T) {
+	for _, code := range []string{"7", "11"} {
+		t.Run(code, func(t *testing.T) {
+			client, mux, teardown := setupClient()
+			defer teardown()
+
+			mux.HandleFunc("/Domain.Create", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprintf(w, `{"status": {"code":"%s","message":"Domain already exists"}}`, code)
+			})
+
+			mux.HandleFunc("/Domain.Acquire", func(w http.ResponseWriter, r *http.Request) {
+				t.Error("a domain of the account should not be acquired")
+			})
+
+			handler := DomainAcquireHandler{
+				Code: func(ctx context.Context, email string) (string, error) {
+					return "123456", nil
+				},
+			}
+
+			_, _, err := client.Domains.CreateOrAcquire(context.Background(), Domain{Name: "example.com"}, handler)
+			if !errors.Is(err, ErrDomainExists) {
+				t.Errorf("got %v, want %v", err, ErrDomainExists)
+			}
+		})
+	}
+}
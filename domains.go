@@ -1,9 +1,13 @@
 package dnspod
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -13,8 +17,18 @@ const (
 	methodDomainInfo   = "Domain.Info"
 	methodDomainRemove = "Domain.Remove"
 	methodRecordLine   = "Record.Line"
+
+	methodDomainStatus           = "Domain.Status"
+	methodDomainLock             = "Domain.Lock"
+	methodDomainLockStatus       = "Domain.Lockstatus"
+	methodDomainUnlock           = "Domain.Unlock"
+	methodDomainRemark           = "Domain.Remark"
+	methodDomainIsMark           = "Domain.Ismark"
+	methodDomainSearchEnginePush = "Domain.Searchenginepush"
 )
 
+const defaultDomainPageLength = 3000
+
 // DomainInfo handles domain information.
 type DomainInfo struct {
 	DomainTotal   json.Number `json:"domain_total,omitempty"`
@@ -29,6 +43,11 @@ type DomainInfo struct {
 	SpamTotal     json.Number `json:"spam_total,omitempty"`
 	VipExpire     json.Number `json:"vip_expire,omitempty"`
 	ShareOutTotal json.Number `json:"share_out_total,omitempty"`
+
+	// Record list information.
+	SubDomains  json.Number `json:"sub_domains,omitempty"`
+	RecordTotal json.Number `json:"record_total,omitempty"`
+	RecordsNum  json.Number `json:"records_num,omitempty"`
 }
 
 // Domain handles domain.
@@ -65,19 +84,52 @@ type DomainCreateResp struct {
 }
 
 type domainListWrapper struct {
-	Status  Status     `json:"status"`
 	Info    DomainInfo `json:"info"`
 	Domains []Domain   `json:"domains"`
 }
 
 type domainWrapper struct {
-	Status Status     `json:"status"`
 	Info   DomainInfo `json:"info"`
 	Domain Domain     `json:"domain"`
 }
 
+// DomainLock is a lock set on a domain.
+type DomainLock struct {
+	DomainID json.Number `json:"domain_id,omitempty"`
+	LockCode string      `json:"lock_code,omitempty"`
+	LockEnd  string      `json:"lock_end,omitempty"`
+}
+
+// DomainLockStatus is the lock status of a domain.
+type DomainLockStatus struct {
+	Lock     string `json:"lock,omitempty"` // yes or no
+	LockDate string `json:"lock_date,omitempty"`
+	LockEnd  string `json:"lock_end,omitempty"`
+}
+
+// Locked reports whether the domain is locked.
+func (l DomainLockStatus) Locked() bool {
+	return l.Lock == "yes"
+}
+
+// DomainStatus is the status of a domain.
+type DomainStatus string
+
+// Domain statuses.
+const (
+	DomainStatusEnable  DomainStatus = "enable"
+	DomainStatusDisable DomainStatus = "disable"
+)
+
+type domainLockWrapper struct {
+	Lock DomainLock `json:"lock"`
+}
+
+type domainLockStatusWrapper struct {
+	Lock DomainLockStatus `json:"lock"`
+}
+
 type domainCreateWrapper struct {
-	Status Status           `json:"status"`
 	Domain DomainCreateResp `json:"domain"`
 }
 
@@ -90,113 +142,361 @@ type DomainsService struct {
 	client *Client
 }
 
-// List the domains.
+// DomainListOptions are the filters and pagination of the domain list.
+type DomainListOptions struct {
+	// Type of the domains: all, mine, share, ismark, pause, vip, recent or share_out.
+	Type string
+
+	// Offset of the first domain.
+	Offset int
+
+	// Length is the number of domains per page.
+	// Defaults to 3000.
+	Length int
+
+	GroupID string
+	Keyword string
+}
+
+func (o DomainListOptions) setPayload(payload url.Values) {
+	if o.Type != "" {
+		payload.Set("type", o.Type)
+	}
+
+	payload.Set("offset", strconv.Itoa(o.Offset))
+	payload.Set("length", strconv.Itoa(o.Length))
+
+	if o.GroupID != "" {
+		payload.Set("group_id", o.GroupID)
+	}
+
+	if o.Keyword != "" {
+		payload.Set("keyword", o.Keyword)
+	}
+}
+
+// DomainPage is a page of the domain list.
+type DomainPage struct {
+	Info    DomainInfo
+	Domains []Domain
+}
+
+// DomainPager fetches the pages of the domain list lazily, one call per page.
+type DomainPager struct {
+	service *DomainsService
+	opts    DomainListOptions
+	done    bool
+}
+
+// HasNext reports whether there are pages left to fetch.
+func (p *DomainPager) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page.
+// An empty page is returned once all the domains have been fetched.
+func (p *DomainPager) Next(ctx context.Context) (DomainPage, *Response, error) {
+	if p.done {
+		return DomainPage{}, nil, nil
+	}
+
+	page, res, err := p.service.client.backend.ListDomains(ctx, p.opts)
+	if errors.Is(err, ErrEmptyResult) {
+		p.done = true
+		return DomainPage{}, res, nil
+	}
+	if err != nil {
+		return DomainPage{}, res, err
+	}
+
+	p.opts.Offset += len(page.Domains)
+
+	// the total is authoritative when known, as the API may cap the length of the pages.
+	total, errTotal := page.Info.DomainTotal.Int64()
+	switch {
+	case len(page.Domains) == 0:
+		p.done = true
+	case errTotal == nil:
+		p.done = int64(p.opts.Offset) >= total
+	default:
+		p.done = len(page.Domains) < p.opts.Length
+	}
+
+	return page, res, nil
+}
+
+// DomainIterator iterates over the domains, fetching the pages lazily.
+//
+//	it := client.Domains.Iter(dnspod.DomainListOptions{})
+//	for it.Next(ctx) {
+//		domain := it.Domain()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type DomainIterator struct {
+	pager   *DomainPager
+	domains []Domain
+	current Domain
+	err     error
+}
+
+// Next advances the iterator to the next domain, fetching the next page if needed.
+// It returns false when there are no domains left, or when an error occurred.
+func (it *DomainIterator) Next(ctx context.Context) bool {
+	for len(it.domains) == 0 {
+		if it.err != nil || !it.pager.HasNext() {
+			return false
+		}
+
+		var page DomainPage
+		page, _, it.err = it.pager.Next(ctx)
+		it.domains = page.Domains
+	}
+
+	it.current, it.domains = it.domains[0], it.domains[1:]
+
+	return true
+}
+
+// Domain returns the current domain.
+func (it *DomainIterator) Domain() Domain {
+	return it.current
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *DomainIterator) Err() error {
+	return it.err
+}
+
+// ListPages returns a pager over the domain list.
 //
 // DNSPod API docs:
 // - https://www.dnspod.cn/docs/domains.html#domain-list
 // - https://docs.dnspod.com/api/5fe1b40a6e336701a2111f5b/
-func (s *DomainsService) List() ([]Domain, *Response, error) {
-	payload := s.client.CommonParams.toPayLoad()
-	payload.Set("length", fmt.Sprintf("%d", 3000))
+func (s *DomainsService) ListPages(opts DomainListOptions) *DomainPager {
+	if opts.Length <= 0 {
+		opts.Length = defaultDomainPageLength
+	}
+
+	return &DomainPager{service: s, opts: opts}
+}
+
+// Iter returns an iterator over the domains.
+func (s *DomainsService) Iter(opts DomainListOptions) *DomainIterator {
+	return &DomainIterator{pager: s.ListPages(opts)}
+}
+
+// ListAll fetches all the domains matching the options, page by page.
+// The response of the last call is returned.
+func (s *DomainsService) ListAll(ctx context.Context, opts DomainListOptions) ([]Domain, *Response, error) {
+	pager := s.ListPages(opts)
 
 	var all []Domain
-	times := 0
-GETALLDOMAINS:
-	payload.Set("offset", fmt.Sprintf("%d", times*3000))
-	returnedDomains := domainListWrapper{}
-	res, err := s.client.post(methodDomainList, payload, &returnedDomains)
-	if err != nil {
-		return nil, res, err
-	}
+	var res *Response
 
-	if returnedDomains.Status.Code != "1" {
-		return nil, nil, fmt.Errorf("could not get domains: %s", returnedDomains.Status.Message)
-	}
-	all = append(all, returnedDomains.Domains...)
-	total, err := returnedDomains.Info.AllTotal.Int64()
-	if err != nil {
-		return nil, nil, err
-	}
-	if int64(len(all)) < total {
-		times++
-		goto GETALLDOMAINS
+	for pager.HasNext() {
+		var page DomainPage
+		var err error
+
+		page, res, err = pager.Next(ctx)
+		if err != nil {
+			return nil, res, err
+		}
+
+		all = append(all, page.Domains...)
 	}
 
-	return returnedDomains.Domains, res, nil
+	return all, res, nil
+}
+
+// List lists the domains.
+//
+// Deprecated: use ListWithContext. List will be removed in the next release.
+func (s *DomainsService) List() ([]Domain, *Response, error) {
+	return s.ListWithContext(context.Background())
+}
+
+// ListWithContext lists the domains.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-list
+// - https://docs.dnspod.com/api/5fe1b40a6e336701a2111f5b/
+func (s *DomainsService) ListWithContext(ctx context.Context) ([]Domain, *Response, error) {
+	return s.ListAll(ctx, DomainListOptions{})
+}
+
+// Create creates a new domain.
+//
+// Deprecated: use CreateWithContext. Create will be removed in the next release.
+func (s *DomainsService) Create(domainAttributes Domain) (DomainCreateResp, *Response, error) {
+	return s.CreateWithContext(context.Background(), domainAttributes)
 }
 
-// Create a new domain.
+// CreateWithContext creates a new domain.
 //
 // DNSPod API docs:
 // - https://www.dnspod.cn/docs/domains.html#domain-create
 // - https://docs.dnspod.com/api/5fe1a9e36e336701a2111d3d/
-func (s *DomainsService) Create(domainAttributes Domain) (DomainCreateResp, *Response, error) {
-	payload := s.client.CommonParams.toPayLoad()
-	payload.Set("domain", domainAttributes.Name)
-	payload.Set("group_id", domainAttributes.GroupID.String())
-	payload.Set("is_mark", domainAttributes.IsMark)
+func (s *DomainsService) CreateWithContext(ctx context.Context, domainAttributes Domain) (DomainCreateResp, *Response, error) {
+	return s.client.backend.CreateDomain(ctx, domainAttributes)
+}
 
-	returnedDomain := domainCreateWrapper{}
+// Get fetches a domain.
+//
+// Deprecated: use GetWithContext. Get will be removed in the next release.
+func (s *DomainsService) Get(domainId string) (Domain, *Response, error) {
+	return s.GetWithContext(context.Background(), domainId)
+}
 
-	res, err := s.client.post(methodDomainCreate, payload, &returnedDomain)
+// GetWithContext fetches a domain.
+// The domain can be given by its name or by its id.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-info
+// - https://docs.dnspod.com/api/5fe1b37d6e336701a2111f2b/
+func (s *DomainsService) GetWithContext(ctx context.Context, domainId string) (Domain, *Response, error) {
+	domain, res, err := s.client.backend.GetDomain(ctx, domainId)
 	if err != nil {
-		return DomainCreateResp{}, res, err
+		return Domain{}, res, err
 	}
 
-	if returnedDomain.Status.Code != "1" {
-		return DomainCreateResp{}, nil, fmt.Errorf("code: %s,message: %s", returnedDomain.Status.Code, returnedDomain.Status.Message)
-	}
+	s.client.LineResolver.learn(domain)
 
-	return returnedDomain.Domain, res, nil
+	return domain, res, nil
 }
 
-// Get fetches a domain.
+// Delete deletes a domain.
 //
-// Parameter can be domain or domain id
+// Deprecated: use DeleteWithContext. Delete will be removed in the next release.
+func (s *DomainsService) Delete(domainId string) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), domainId)
+}
+
+// DeleteWithContext deletes a domain.
 //
 // DNSPod API docs:
-// - https://www.dnspod.cn/docs/domains.html#domain-info
-// - https://docs.dnspod.com/api/5fe1b37d6e336701a2111f2b/
-func (s *DomainsService) Get(domainId string) (Domain, *Response, error) {
+// - https://dnsapi.cn/Domain.Remove
+// - https://docs.dnspod.com/api/5fe1ac446e336701a2111dd1/
+func (s *DomainsService) DeleteWithContext(ctx context.Context, domainId string) (*Response, error) {
+	return s.client.backend.DeleteDomain(ctx, domainId)
+}
+
+// SetStatus enables or disables a domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-status
+func (s *DomainsService) SetStatus(ctx context.Context, domainID string, status DomainStatus) (*Response, error) {
 	payload := s.client.CommonParams.toPayLoad()
-	if strings.Contains(domainId, ".") {
-		// must be domain if contain dot
-		payload.Set("domain", domainId)
-	} else {
-		payload.Set("domain_id", domainId)
-	}
+	setDomainParam(payload, domainID)
+	payload.Set("status", string(status))
+
+	return s.client.post(ctx, methodDomainStatus, payload, nil)
+}
+
+// Lock locks a domain for a number of days.
+// The returned lock code is needed to unlock the domain before the end of the lock.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-lock
+func (s *DomainsService) Lock(ctx context.Context, domainID string, days int) (DomainLock, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("days", strconv.Itoa(days))
 
-	returnedDomain := domainWrapper{}
+	returnedLock := domainLockWrapper{}
 
-	res, err := s.client.post(methodDomainInfo, payload, &returnedDomain)
+	res, err := s.client.post(ctx, methodDomainLock, payload, &returnedLock)
 	if err != nil {
-		return Domain{}, res, err
+		return DomainLock{}, res, err
 	}
 
-	return returnedDomain.Domain, res, nil
+	return returnedLock.Lock, res, nil
 }
 
-// Delete a domain.
+// LockStatus fetches the lock status of a domain.
 //
 // DNSPod API docs:
-// - https://dnsapi.cn/Domain.Remove
-// - https://docs.dnspod.com/api/5fe1ac446e336701a2111dd1/
-func (s *DomainsService) Delete(domainId string) (*Response, error) {
+// - https://www.dnspod.cn/docs/domains.html#domain-lockstatus
+func (s *DomainsService) LockStatus(ctx context.Context, domainID string) (DomainLockStatus, *Response, error) {
 	payload := s.client.CommonParams.toPayLoad()
-	payload.Set("domain_id", domainId)
+	setDomainParam(payload, domainID)
 
-	returnedDomain := domainWrapper{}
+	returnedLock := domainLockStatusWrapper{}
 
-	res, err := s.client.post(methodDomainRemove, payload, &returnedDomain)
+	res, err := s.client.post(ctx, methodDomainLockStatus, payload, &returnedLock)
 	if err != nil {
-		return nil, err
+		return DomainLockStatus{}, res, err
 	}
 
-	if returnedDomain.Status.Code != "1" {
-		return nil, fmt.Errorf("code: %s, message: %s", returnedDomain.Status.Code, returnedDomain.Status.Message)
+	return returnedLock.Lock, res, nil
+}
+
+// Unlock unlocks a domain with the code returned by Lock.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-unlock
+func (s *DomainsService) Unlock(ctx context.Context, domainID, lockCode string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("lock_code", lockCode)
+
+	return s.client.post(ctx, methodDomainUnlock, payload, nil)
+}
+
+// SetRemark sets the remark of a domain. An empty remark removes it.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-remark
+func (s *DomainsService) SetRemark(ctx context.Context, domainID, remark string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("remark", remark)
+
+	return s.client.post(ctx, methodDomainRemark, payload, nil)
+}
+
+// SetMark stars or unstars a domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-ismark
+func (s *DomainsService) SetMark(ctx context.Context, domainID string, marked bool) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("is_mark", yesNo(marked))
+
+	return s.client.post(ctx, methodDomainIsMark, payload, nil)
+}
+
+// SetSearchEnginePush enables or disables the push of the domain records to the search engines.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/domains.html#domain-searchenginepush
+func (s *DomainsService) SetSearchEnginePush(ctx context.Context, domainID string, enabled bool) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("status", yesNo(enabled))
+
+	return s.client.post(ctx, methodDomainSearchEnginePush, payload, nil)
+}
+
+// setDomainParam sets the domain name, or the domain id, of a request.
+func setDomainParam(payload url.Values, domainID string) {
+	if strings.Contains(domainID, ".") {
+		// must be domain if contain dot
+		payload.Set("domain", domainID)
+	} else {
+		payload.Set("domain_id", domainID)
 	}
+}
 
-	return res, nil
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
 }
 
 type Line struct {
@@ -211,21 +511,17 @@ type LineInfo struct {
 
 // GetLines
 //
-// get lines of record which group by grade of domain
-// valid grade: D_Free,D_Plus,D_Extra,D_Expert,D_Ultra,DP_Free,DP_Plus,DP_Extra,DP_Expert,DP_Ultra
+// Deprecated: use GetLinesWithContext. GetLines will be removed in the next release.
 func (s *DomainsService) GetLines(domain, domainGrade string) ([]Line, *Response, error) {
-	grade := []string{
-		"D_Free", "D_Plus", "D_Extra", "D_Expert", "D_Ultra",
-		"DP_Free", "DP_Plus", "DP_Extra", "DP_Expert", "DP_Ultra",
-		"DPG_Free", "DPG_Plus", "DPG_Extra", "DPG_Expert", "DPG_Ultra",
-	}
-	ok := false
-	for _, i := range grade {
-		if i == domainGrade {
-			ok = true
-		}
-	}
-	if !ok {
+	return s.GetLinesWithContext(context.Background(), domain, domainGrade)
+}
+
+// GetLinesWithContext
+//
+// get lines of record which group by grade of domain
+// valid grade: D_Free,D_Plus,D_Extra,D_Expert,D_Ultra,DP_Free,DP_Plus,DP_Extra,DP_Expert,DP_Ultra,DPG_Free,DPG_Plus,DPG_Extra,DPG_Expert,DPG_Ultra
+func (s *DomainsService) GetLinesWithContext(ctx context.Context, domain, domainGrade string) ([]Line, *Response, error) {
+	if !validGrade(domainGrade) {
 		return nil, nil, fmt.Errorf("invalid grade of domain: %s", domainGrade)
 	}
 
@@ -240,13 +536,9 @@ func (s *DomainsService) GetLines(domain, domainGrade string) ([]Line, *Response
 
 	returnedLines := LineInfo{}
 
-	res, err := s.client.post(methodRecordLine, payload, &returnedLines)
+	res, err := s.client.post(ctx, methodRecordLine, payload, &returnedLines)
 	if err != nil {
-		return nil, nil, err
-	}
-
-	if returnedLines.Status.Code != "1" {
-		return nil, nil, fmt.Errorf("code: %s, message: %s", returnedLines.Status.Code, returnedLines.Status.Message)
+		return nil, res, err
 	}
 
 	var items []Line
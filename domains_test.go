@@ -1,6 +1,8 @@
 package dnspod
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -19,6 +21,7 @@ func TestDomainsService_List(t *testing.T) {
 
 		_, _ = fmt.Fprint(w, `{
 			"status": {"code":"1","message":""},
+			"info": {"domain_total":2,"all_total":2},
 			"domains": [
 				{
 					"id": 2238269,
@@ -33,7 +36,7 @@ func TestDomainsService_List(t *testing.T) {
 			]}`)
 	})
 
-	domains, _, err := client.Domains.List()
+	domains, _, err := client.Domains.ListWithContext(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -56,6 +59,7 @@ func TestDomainsService_List_Ambiguous_Value(t *testing.T) {
 
 		_, _ = fmt.Fprint(w, `{
 			"status": {"code":"1","message":""},
+			"info": {"domain_total":2,"all_total":2},
 			"domains": [
 				{
 					"id": 2238269,
@@ -81,6 +85,97 @@ func TestDomainsService_List_Ambiguous_Value(t *testing.T) {
 	}
 }
 
+func TestDomainsService_ListAll(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.List", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("length") != "2" || r.FormValue("keyword") != "example" {
+			http.Error(w, "unexpected filters", http.StatusBadRequest)
+			return
+		}
+
+		switch r.FormValue("offset") {
+		case "0":
+			_, _ = fmt.Fprint(w, `{"status": {"code":"1"}, "info": {"domain_total":3}, "domains": [{"id":1},{"id":2}]}`)
+		case "2":
+			_, _ = fmt.Fprint(w, `{"status": {"code":"1"}, "info": {"domain_total":3}, "domains": [{"id":3}]}`)
+		default:
+			http.Error(w, "unexpected offset", http.StatusBadRequest)
+		}
+	})
+
+	domains, _, err := client.Domains.ListAll(context.Background(), DomainListOptions{Length: 2, Keyword: "example"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Domain{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	if !reflect.DeepEqual(domains, want) {
+		t.Errorf("got %+v, want %+v", domains, want)
+	}
+}
+
+func TestDomainsService_ListAll_cappedPages(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	// the server returns at most 2 domains per page, whatever the length.
+	mux.HandleFunc("/Domain.List", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("offset") {
+		case "0":
+			_, _ = fmt.Fprint(w, `{"status": {"code":"1"}, "info": {"domain_total":3}, "domains": [{"id":1},{"id":2}]}`)
+		case "2":
+			_, _ = fmt.Fprint(w, `{"status": {"code":"1"}, "info": {"domain_total":3}, "domains": [{"id":3}]}`)
+		default:
+			http.Error(w, "unexpected offset", http.StatusBadRequest)
+		}
+	})
+
+	domains, _, err := client.Domains.ListAll(context.Background(), DomainListOptions{Length: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Domain{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	if !reflect.DeepEqual(domains, want) {
+		t.Errorf("got %+v, want %+v", domains, want)
+	}
+}
+
+func TestDomainsService_Iter(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/Domain.List", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if r.FormValue("offset") == "1" {
+			_, _ = fmt.Fprint(w, `{"status": {"code":"9","message":"No domains"}}`)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1"}, "domains": [{"id":1}]}`)
+	})
+
+	ctx := context.Background()
+	it := client.Domains.Iter(DomainListOptions{Length: 1})
+
+	var ids []string
+	for it.Next(ctx) {
+		ids = append(ids, it.Domain().ID.String())
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(ids, []string{"1"}) || calls != 2 {
+		t.Errorf("got %v in %d calls, want [1] in 2 calls", ids, calls)
+	}
+}
+
 func TestDomainsService_Create(t *testing.T) {
 	client, mux, teardown := setupClient()
 	defer teardown()
@@ -92,7 +187,7 @@ func TestDomainsService_Create(t *testing.T) {
 		}
 
 		w.WriteHeader(http.StatusCreated)
-		_, _ = fmt.Fprintf(w, `{"status": {"code":"1","message":""},"domain":{"id":1, "name":"example.com"}}`)
+		_, _ = fmt.Fprintf(w, `{"status": {"code":"1","message":""},"domain":{"id":"1", "punycode":"example.com", "domain":"example.com"}}`)
 	})
 
 	domainValues := Domain{Name: "example.com"}
@@ -101,7 +196,7 @@ func TestDomainsService_Create(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := Domain{ID: "1", Name: "example.com"}
+	want := DomainCreateResp{Id: "1", Punycode: "example.com", Domain: "example.com"}
 	if !reflect.DeepEqual(domain, want) {
 		t.Errorf("got %+v, want %+v", domain, want)
 	}
@@ -120,7 +215,7 @@ func TestDomainsService_Get(t *testing.T) {
 		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"domain": {"id":1, "name":"example.com"}}`)
 	})
 
-	domain, _, err := client.Domains.Get(1)
+	domain, _, err := client.Domains.Get("1")
 	if err != nil {
 		t.Errorf("Domains.Get returned error: %v", err)
 	}
@@ -144,8 +239,125 @@ func TestDomainsService_Delete(t *testing.T) {
 		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
 	})
 
-	_, err := client.Domains.Delete(1)
+	_, err := client.Domains.Delete("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDomainsService_Delete_byName(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Remove", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain") != "example.com" || r.FormValue("domain_id") != "" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
+	})
+
+	_, err := client.Domains.DeleteWithContext(context.Background(), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDomainsService_GetLines(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Record.Line", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain_grade") != "DP_Free" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"line_ids":{"默认":0,"电信":"10=1"}}`)
+	})
+
+	lines, _, err := client.Domains.GetLines("example.com", "DP_Free")
 	if err != nil {
 		t.Fatal(err)
 	}
+
+	want := []Line{{LineName: "默认", LineId: "0"}, {LineName: "电信", LineId: "10=1"}}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("got %+v, want %+v", lines, want)
+	}
+}
+
+func TestDomainsService_Get_failed(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Info", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"6","message":"Domain id invalid"}}`)
+	})
+
+	_, res, err := client.Domains.GetWithContext(context.Background(), "1")
+	if !errors.Is(err, ErrDomainNotFound) {
+		t.Errorf("got %v, want %v", err, ErrDomainNotFound)
+	}
+
+	if res == nil {
+		t.Error("response should be returned along with the error")
+	}
+}
+
+func TestDomainsService_SetStatus(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Status", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain") != "example.com" || r.FormValue("status") != "disable" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
+	})
+
+	_, err := client.Domains.SetStatus(context.Background(), "example.com", DomainStatusDisable)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDomainsService_Lock(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Lock", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain_id") != "2059079" || r.FormValue("days") != "3" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"lock":{"domain_id":2059079,"lock_code":"c3b8b7","lock_end":"2012-11-07"}}`)
+	})
+
+	mux.HandleFunc("/Domain.Lockstatus", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"lock":{"lock":"yes","lock_date":"2012-11-04","lock_end":"2012-11-07"}}`)
+	})
+
+	lock, _, err := client.Domains.Lock(context.Background(), "2059079", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := DomainLock{DomainID: "2059079", LockCode: "c3b8b7", LockEnd: "2012-11-07"}
+	if !reflect.DeepEqual(lock, want) {
+		t.Errorf("got %+v, want %+v", lock, want)
+	}
+
+	status, _, err := client.Domains.LockStatus(context.Background(), "2059079")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !status.Locked() || status.LockEnd != "2012-11-07" {
+		t.Errorf("got %+v, want a lock ending on 2012-11-07", status)
+	}
 }
@@ -0,0 +1,175 @@
+package dnspod
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for the well-known DNSPod status codes.
+// They can be matched with errors.Is against any error returned by the services.
+var (
+	ErrLoginFailed      = errors.New("dnspod: login failed")
+	ErrRateLimited      = errors.New("dnspod: API usage limit exceeded")
+	ErrNoPermission     = errors.New("dnspod: no permission")
+	ErrServiceSuspended = errors.New("dnspod: service suspended")
+	ErrAccountAbnormal  = errors.New("dnspod: account abnormal")
+	ErrDomainNotFound   = errors.New("dnspod: domain not found")
+	ErrDomainExists     = errors.New("dnspod: domain already exists")
+	ErrDomainTaken      = errors.New("dnspod: domain already added by another account")
+	ErrDomainLocked     = errors.New("dnspod: domain locked")
+	ErrDomainBanned     = errors.New("dnspod: domain banned")
+	ErrRecordNotFound   = errors.New("dnspod: record not found")
+	ErrEmptyResult      = errors.New("dnspod: empty result")
+	ErrLineNotFound     = errors.New("dnspod: line not found")
+	ErrUnsupported      = errors.New("dnspod: method not supported by the API")
+)
+
+// globalErrorCodes are the status codes shared by every method.
+//
+// DNSPod API docs:
+// - https://docs.dnspod.cn/api/5f561f9ee75cf42d25bf6720/
+var globalErrorCodes = map[string]error{
+	"-1":  ErrLoginFailed,
+	"-2":  ErrRateLimited,
+	"-7":  ErrNoPermission,
+	"-8":  ErrLoginFailed,
+	"-15": ErrDomainBanned,
+	"-99": ErrServiceSuspended,
+	"85":  ErrAccountAbnormal,
+
+	// Tencent Cloud API 3.0
+	"AuthFailure.InvalidSecretId":       ErrLoginFailed,
+	"AuthFailure.SecretIdNotFound":      ErrLoginFailed,
+	"AuthFailure.SignatureExpire":       ErrLoginFailed,
+	"AuthFailure.SignatureFailure":      ErrLoginFailed,
+	"AuthFailure.UnauthorizedOperation": ErrNoPermission,
+	"UnauthorizedOperation":             ErrNoPermission,
+	"RequestLimitExceeded":              ErrRateLimited,
+}
+
+// tencentCloudDomainErrorCodes are the error codes of the Tencent Cloud API shared by the actions on a domain.
+var tencentCloudDomainErrorCodes = map[string]error{
+	"InvalidParameterValue.DomainNotExists": ErrDomainNotFound,
+	"ResourceNotFound.NoDataOfDomain":       ErrDomainNotFound,
+	"FailedOperation.DomainIsLocked":        ErrDomainLocked,
+}
+
+// tencentCloudRecordErrorCodes are the error codes of the Tencent Cloud API shared by the actions on a record.
+var tencentCloudRecordErrorCodes = map[string]error{
+	"InvalidParameterValue.DomainNotExists": ErrDomainNotFound,
+	"InvalidParameter.RecordIdInvalid":      ErrRecordNotFound,
+	"FailedOperation.DomainIsLocked":        ErrDomainLocked,
+}
+
+// domainErrorCodes are the status codes shared by the methods acting on a single domain.
+var domainErrorCodes = map[string]error{
+	"6": ErrDomainNotFound,
+	"8": ErrNoPermission,
+}
+
+// recordErrorCodes are the status codes shared by the methods acting on a single record.
+var recordErrorCodes = map[string]error{
+	"6":  ErrDomainNotFound,
+	"8":  ErrRecordNotFound,
+	"21": ErrDomainLocked,
+}
+
+// methodErrorCodes are the status codes whose meaning depends on the API method.
+var methodErrorCodes = map[string]map[string]error{
+	methodDomainList:   {"9": ErrEmptyResult},
+	methodDomainCreate: {"7": ErrDomainExists, "11": ErrDomainExists, "12": ErrDomainTaken},
+	methodDomainInfo:   domainErrorCodes,
+	methodDomainRemove: domainErrorCodes,
+
+	methodDomainStatus:           domainErrorCodes,
+	methodDomainLock:             domainErrorCodes,
+	methodDomainLockStatus:       domainErrorCodes,
+	methodDomainUnlock:           domainErrorCodes,
+	methodDomainRemark:           domainErrorCodes,
+	methodDomainIsMark:           domainErrorCodes,
+	methodDomainSearchEnginePush: domainErrorCodes,
+	methodDomainChangeGroup:      domainErrorCodes,
+	methodDomainShare:            domainErrorCodes,
+	methodDomainShareList:        domainErrorCodes,
+	methodDomainShareModify:      domainErrorCodes,
+	methodDomainShareRemove:      domainErrorCodes,
+	methodDomainAliasList:        domainErrorCodes,
+	methodDomainAliasAdd:         domainErrorCodes,
+	methodDomainAliasRemove:      domainErrorCodes,
+	methodDomainTransfer:         domainErrorCodes,
+	methodDomainLog:              domainErrorCodes,
+	methodDomainPurview:          domainErrorCodes,
+
+	methodSnapshotList:     domainErrorCodes,
+	methodSnapshotCreate:   domainErrorCodes,
+	methodSnapshotRemove:   domainErrorCodes,
+	methodSnapshotRollback: domainErrorCodes,
+	methodSnapshotConfig:   domainErrorCodes,
+	methodSnapshotDownload: domainErrorCodes,
+
+	methodRecordLine:   {"6": ErrDomainNotFound},
+	methodRecordList:   {"6": ErrDomainNotFound, "10": ErrEmptyResult},
+	methodRecordCreate: recordErrorCodes,
+	methodRecordInfo:   recordErrorCodes,
+	methodRecordRemove: recordErrorCodes,
+	methodRecordModify: recordErrorCodes,
+	methodRecordRemark: recordErrorCodes,
+	methodRecordStatus: recordErrorCodes,
+	methodRecordDdns:   recordErrorCodes,
+
+	actionDescribeDomainList: {"ResourceNotFound.NoDataOfDomain": ErrEmptyResult},
+	actionDescribeDomain:     tencentCloudDomainErrorCodes,
+	actionCreateDomain:       {"FailedOperation.DomainExists": ErrDomainExists, "FailedOperation.DomainOwnedByOtherUser": ErrDomainTaken},
+	actionDeleteDomain:       tencentCloudDomainErrorCodes,
+	actionDescribeRecordList: {"InvalidParameterValue.DomainNotExists": ErrDomainNotFound, "ResourceNotFound.NoDataOfRecord": ErrEmptyResult},
+	actionDescribeRecord:     tencentCloudRecordErrorCodes,
+	actionCreateRecord:       tencentCloudRecordErrorCodes,
+	actionModifyRecord:       tencentCloudRecordErrorCodes,
+	actionDeleteRecord:       tencentCloudRecordErrorCodes,
+}
+
+// lookupErrorCode returns the sentinel error matching the status code of the method, if any.
+func lookupErrorCode(method, code string) error {
+	if err, ok := methodErrorCodes[method][code]; ok {
+		return err
+	}
+
+	return globalErrorCodes[code]
+}
+
+// APIError represents a failure reported by the DNSPod API in the status of a response.
+//
+// APIError wraps the sentinel error matching its code (e.g. ErrDomainNotFound), if any.
+type APIError struct {
+	Code      string
+	Message   string
+	Method    string
+	RequestID string
+
+	Response *Response // API response that caused this error
+}
+
+func newAPIError(method string, status Status, res *Response) *APIError {
+	apiErr := &APIError{
+		Code:     status.Code,
+		Message:  status.Message,
+		Method:   method,
+		Response: res,
+	}
+
+	if res != nil && res.Response != nil {
+		apiErr.RequestID = res.Header.Get("X-Request-Id")
+	}
+
+	return apiErr
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: code: %s, message: %s", e.Method, e.Code, e.Message)
+}
+
+// Unwrap returns the sentinel error matching the code, or nil for unknown codes.
+func (e *APIError) Unwrap() error {
+	return lookupErrorCode(e.Method, e.Code)
+}
@@ -0,0 +1,70 @@
+package dnspod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAPIError_sentinel(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Record.Info", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"8","message":"Record id invalid"}}`)
+	})
+
+	_, _, err := client.Records.GetWithContext(context.Background(), "44146112", "26954449")
+	if !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("got %v, want %v", err, ErrRecordNotFound)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %T, want *APIError", err)
+	}
+
+	want := APIError{Code: "8", Message: "Record id invalid", Method: methodRecordInfo}
+	if apiErr.Code != want.Code || apiErr.Message != want.Message || apiErr.Method != want.Method {
+		t.Errorf("got %+v, want %+v", apiErr, want)
+	}
+}
+
+func TestAPIError_methodSpecificCode(t *testing.T) {
+	testCases := []struct {
+		method string
+		code   string
+		want   error
+	}{
+		{method: methodDomainCreate, code: "7", want: ErrDomainExists},
+		{method: methodDomainInfo, code: "6", want: ErrDomainNotFound},
+		{method: methodRecordList, code: "10", want: ErrEmptyResult},
+		{method: methodRecordList, code: "-1", want: ErrLoginFailed},
+		{method: methodRecordList, code: "-2", want: ErrRateLimited},
+		{method: methodDomainList, code: "10", want: nil},
+	}
+
+	for _, test := range testCases {
+		err := newAPIError(test.method, Status{Code: test.code}, nil)
+		if got := errors.Unwrap(err); !errors.Is(got, test.want) {
+			t.Errorf("%s code %s: got %v, want %v", test.method, test.code, got, test.want)
+		}
+	}
+}
+
+func TestErrorResponse_sentinel(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Record.Remove", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = fmt.Fprint(w, `{"message":"Too many requests"}`)
+	})
+
+	_, err := client.Records.DeleteWithContext(context.Background(), "44146112", "26954449")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("got %v, want %v", err, ErrRateLimited)
+	}
+}
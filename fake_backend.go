@@ -0,0 +1,450 @@
+package dnspod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FakeBackend is an in-memory API, to test code using the DomainsService and the RecordsService without HTTP.
+//
+//	client := dnspod.NewClient(dnspod.CommonParams{}, dnspod.WithBackend(dnspod.NewFakeBackend()))
+//
+// It mimics the legacy DNSPod API: the domains are found by id or by name,
+// the errors are the sentinel errors (e.g. ErrDomainNotFound), and the responses are nil.
+// The domains can be listed by the types all, mine, share, ismark and pause, and a negative offset is rejected.
+// Besides the methods of the domains and the records, it serves Record.Line, Record.Type, Record.Status, Record.Ddns, Record.Remark,
+// Domain.Status and Domain.Remark, with the same lines and record types for all the grades.
+// It is safe for concurrent use.
+type FakeBackend struct {
+	mu      sync.Mutex
+	lastID  int
+	domains []*fakeDomain
+}
+
+type fakeDomain struct {
+	domain  Domain
+	records []Record
+}
+
+// NewFakeBackend creates an empty FakeBackend.
+func NewFakeBackend() *FakeBackend {
+	return &FakeBackend{}
+}
+
+// ListDomains implements apiBackend.
+func (b *FakeBackend) ListDomains(_ context.Context, opts DomainListOptions) (DomainPage, *Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if opts.Offset < 0 {
+		return DomainPage{}, nil, fmt.Errorf("invalid offset: %d", opts.Offset)
+	}
+
+	match, err := fakeDomainType(opts.Type)
+	if err != nil {
+		return DomainPage{}, nil, err
+	}
+
+	var domains []Domain
+	for _, d := range b.domains {
+		if !match(d.domain) {
+			continue
+		}
+
+		if opts.Keyword != "" && !strings.Contains(d.domain.Name, opts.Keyword) {
+			continue
+		}
+
+		if opts.GroupID != "" && d.domain.GroupID.String() != opts.GroupID {
+			continue
+		}
+
+		domains = append(domains, d.domain)
+	}
+
+	if len(domains) == 0 {
+		return DomainPage{}, nil, ErrEmptyResult
+	}
+
+	info := DomainInfo{
+		DomainTotal: json.Number(strconv.Itoa(len(domains))),
+		AllTotal:    json.Number(strconv.Itoa(len(b.domains))),
+		MineTotal:   json.Number(strconv.Itoa(len(b.domains))),
+	}
+
+	start, end := pageBounds(len(domains), opts.Offset, opts.Length)
+
+	return DomainPage{Info: info, Domains: domains[start:end]}, nil, nil
+}
+
+// GetDomain implements apiBackend.
+func (b *FakeBackend) GetDomain(_ context.Context, domainID string) (Domain, *Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	d, _ := b.find(domainID)
+	if d == nil {
+		return Domain{}, nil, ErrDomainNotFound
+	}
+
+	return d.domain, nil, nil
+}
+
+// CreateDomain implements apiBackend.
+func (b *FakeBackend) CreateDomain(_ context.Context, domain Domain) (DomainCreateResp, *Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if d, _ := b.find(domain.Name); d != nil {
+		return DomainCreateResp{}, nil, ErrDomainExists
+	}
+
+	created := Domain{
+		ID:       b.nextID(),
+		Name:     domain.Name,
+		PunyCode: domain.Name,
+		Grade:    "DP_Free",
+		Status:   string(DomainStatusEnable),
+		GroupID:  domain.GroupID,
+		IsMark:   domain.IsMark,
+		Remark:   domain.Remark,
+		TTL:      "600",
+	}
+
+	b.domains = append(b.domains, &fakeDomain{domain: created})
+
+	return DomainCreateResp{Id: created.ID.String(), Punycode: created.PunyCode, Domain: created.Name}, nil, nil
+}
+
+// DeleteDomain implements apiBackend.
+func (b *FakeBackend) DeleteDomain(_ context.Context, domainID string) (*Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	d, i := b.find(domainID)
+	if d == nil {
+		return nil, ErrDomainNotFound
+	}
+
+	b.domains = append(b.domains[:i], b.domains[i+1:]...)
+
+	return nil, nil
+}
+
+// ListRecords implements apiBackend.
+func (b *FakeBackend) ListRecords(_ context.Context, domainID string, opts RecordListOptions) (*DomainWithRecords, *Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if opts.Offset < 0 {
+		return nil, nil, fmt.Errorf("invalid offset: %d", opts.Offset)
+	}
+
+	d, _ := b.find(domainID)
+	if d == nil {
+		return nil, nil, ErrDomainNotFound
+	}
+
+	var records []Record
+	subDomains := make(map[string]bool)
+
+	for _, r := range d.records {
+		subDomains[r.Name] = true
+
+		if !opts.match(r) {
+			continue
+		}
+
+		records = append(records, r)
+	}
+
+	if len(records) == 0 {
+		return nil, nil, ErrEmptyResult
+	}
+
+	start, end := pageBounds(len(records), opts.Offset, opts.Length)
+
+	return &DomainWithRecords{
+		Domain: Domain{ID: d.domain.ID, Name: d.domain.Name, PunyCode: d.domain.PunyCode, Grade: d.domain.Grade},
+		Info: DomainInfo{
+			SubDomains:  json.Number(strconv.Itoa(len(subDomains))),
+			RecordTotal: json.Number(strconv.Itoa(len(d.records))),
+			RecordsNum:  json.Number(strconv.Itoa(len(records))),
+		},
+		Records: records[start:end],
+	}, nil, nil
+}
+
+// GetRecord implements apiBackend.
+func (b *FakeBackend) GetRecord(_ context.Context, domainID, recordID string) (Record, *Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	r, err := b.findRecord(domainID, recordID)
+	if err != nil {
+		return Record{}, nil, err
+	}
+
+	return *r, nil, nil
+}
+
+// CreateRecord implements apiBackend.
+// The name, line, TTL and status of the record default to "@", "默认", "600" and "enable".
+func (b *FakeBackend) CreateRecord(_ context.Context, domainID string, record Record) (Record, *Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	d, _ := b.find(domainID)
+	if d == nil {
+		return Record{}, nil, ErrDomainNotFound
+	}
+
+	record.ID = b.nextID().String()
+
+	if record.Name == "" {
+		record.Name = "@"
+	}
+
+	if record.Line == "" && record.LineID == "" {
+		record.Line = "默认"
+	}
+
+	if record.TTL == "" {
+		record.TTL = "600"
+	}
+
+	if record.Status == "" {
+		record.Status = string(RecordStatusEnable)
+	}
+
+	record.Enabled = fakeEnabled(record.Status)
+
+	d.records = append(d.records, record)
+
+	return record, nil, nil
+}
+
+// UpdateRecord implements apiBackend.
+// The empty fields of the record are left unchanged.
+func (b *FakeBackend) UpdateRecord(_ context.Context, domainID, recordID string, record Record) (RecordModify, *Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	r, err := b.findRecord(domainID, recordID)
+	if err != nil {
+		return RecordModify{}, nil, err
+	}
+
+	setNotEmpty(&r.Name, record.Name)
+	setNotEmpty(&r.Line, record.Line)
+	setNotEmpty(&r.LineID, record.LineID)
+	setNotEmpty(&r.TTL, record.TTL)
+	setNotEmpty(&r.Value, record.Value)
+	setNotEmpty(&r.MX, record.MX)
+	setNotEmpty(&r.Status, record.Status)
+
+	if record.Type != "" {
+		r.Type = record.Type
+	}
+
+	if record.Weight != nil {
+		r.Weight = record.Weight
+	}
+
+	r.Enabled = fakeEnabled(r.Status)
+
+	return RecordModify{ID: json.Number(r.ID), Name: r.Name, Value: r.Value, Status: r.Status}, nil, nil
+}
+
+// DeleteRecord implements apiBackend.
+func (b *FakeBackend) DeleteRecord(_ context.Context, domainID, recordID string) (*Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	d, _ := b.find(domainID)
+	if d == nil {
+		return nil, ErrDomainNotFound
+	}
+
+	for i, r := range d.records {
+		if r.ID == recordID {
+			d.records = append(d.records[:i], d.records[i+1:]...)
+			return nil, nil
+		}
+	}
+
+	return nil, ErrRecordNotFound
+}
+
+// fakeLines are the lines returned by Record.Line.
+var fakeLines = map[string]interface{}{"默认": 0, "电信": "10=0", "联通": "10=1", "移动": "10=3", "境外": "3=0"}
+
+// callMethod implements methodCaller.
+func (b *FakeBackend) callMethod(_ context.Context, method string, payload url.Values, v interface{}) (*Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var data interface{}
+
+	switch method {
+	case methodRecordLine:
+		data = map[string]interface{}{"line_ids": fakeLines}
+
+	case methodRecordType:
+		data = recordTypeWrapper{Types: []RecordType{
+			RecordTypeA, RecordTypeAAAA, RecordTypeCNAME, RecordTypeMX, RecordTypeTXT, RecordTypeNS,
+			RecordTypeSRV, RecordTypeCAA, RecordTypeExplicitURL, RecordTypeImplicitURL,
+		}}
+
+	case methodRecordStatus, methodRecordDdns, methodRecordRemark:
+		r, err := b.findRecord(fakeDomainParam(payload), payload.Get("record_id"))
+		if err != nil {
+			return nil, err
+		}
+
+		setNotEmpty(&r.Status, payload.Get("status"))
+		setNotEmpty(&r.Name, payload.Get("sub_domain"))
+		setNotEmpty(&r.Line, payload.Get("record_line"))
+		setNotEmpty(&r.LineID, payload.Get("record_line_id"))
+		setNotEmpty(&r.Value, payload.Get("value"))
+
+		if method == methodRecordRemark {
+			r.Remark = payload.Get("remark")
+		}
+
+		r.Enabled = fakeEnabled(r.Status)
+
+		data = recordModifyWrapper{Record: RecordModify{ID: json.Number(r.ID), Name: r.Name, Value: r.Value, Status: r.Status}}
+
+	case methodDomainStatus, methodDomainRemark:
+		d, _ := b.find(fakeDomainParam(payload))
+		if d == nil {
+			return nil, ErrDomainNotFound
+		}
+
+		setNotEmpty(&d.domain.Status, payload.Get("status"))
+
+		if method == methodDomainRemark {
+			d.domain.Remark = payload.Get("remark")
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, method)
+	}
+
+	if v == nil || data == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, json.Unmarshal(raw, v)
+}
+
+// fakeDomainParam returns the domain of the form parameters, given by id or by name.
+func fakeDomainParam(payload url.Values) string {
+	if id := payload.Get("domain_id"); id != "" {
+		return id
+	}
+
+	return payload.Get("domain")
+}
+
+// find returns the domain with the id or the name, and its index.
+func (b *FakeBackend) find(domainID string) (*fakeDomain, int) {
+	for i, d := range b.domains {
+		if d.domain.ID.String() == domainID || strings.EqualFold(d.domain.Name, domainID) {
+			return d, i
+		}
+	}
+
+	return nil, -1
+}
+
+func (b *FakeBackend) findRecord(domainID, recordID string) (*Record, error) {
+	d, _ := b.find(domainID)
+	if d == nil {
+		return nil, ErrDomainNotFound
+	}
+
+	for i := range d.records {
+		if d.records[i].ID == recordID {
+			return &d.records[i], nil
+		}
+	}
+
+	return nil, ErrRecordNotFound
+}
+
+func (b *FakeBackend) nextID() json.Number {
+	b.lastID++
+	return json.Number(strconv.Itoa(b.lastID))
+}
+
+// match reports whether the record matches the filters of the options.
+func (o RecordListOptions) match(r Record) bool {
+	switch {
+	case o.SubDomain != "" && r.Name != o.SubDomain,
+		o.RecordType != "" && r.Type != o.RecordType,
+		o.RecordLine != "" && r.Line != o.RecordLine,
+		o.RecordLineID != "" && r.LineID != o.RecordLineID,
+		o.RecordID != "" && r.ID != o.RecordID,
+		o.Keyword != "" && !strings.Contains(r.Name, o.Keyword) && !strings.Contains(r.Value, o.Keyword):
+		return false
+	default:
+		return true
+	}
+}
+
+// fakeDomainType returns the filter of the domains of a type of DomainListOptions.
+// All the domains belong to the account: none are shared with it.
+func fakeDomainType(domainType string) (func(Domain) bool, error) {
+	switch domainType {
+	case "", "all", "mine":
+		return func(Domain) bool { return true }, nil
+	case "share":
+		return func(Domain) bool { return false }, nil
+	case "ismark":
+		return func(d Domain) bool { return d.IsMark == "yes" }, nil
+	case "pause":
+		return func(d Domain) bool { return d.Status == string(DomainStatusDisable) || d.Status == "pause" }, nil
+	default:
+		return nil, fmt.Errorf("%w: %s of type %s", ErrUnsupported, methodDomainList, domainType)
+	}
+}
+
+// pageBounds returns the bounds of the page in a list of n items, a zero length meaning all the items.
+func pageBounds(n, offset, length int) (int, int) {
+	if offset > n {
+		offset = n
+	}
+
+	end := n
+	if length > 0 && offset+length < n {
+		end = offset + length
+	}
+
+	return offset, end
+}
+
+func setNotEmpty(dst *string, src string) {
+	if src != "" {
+		*dst = src
+	}
+}
+
+func fakeEnabled(status string) string {
+	if status == string(RecordStatusDisable) {
+		return "0"
+	}
+
+	return "1"
+}
@@ -0,0 +1,211 @@
+package dnspod
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func setupFakeClient(t *testing.T) (*Client, string) {
+	t.Helper()
+
+	client := NewClient(CommonParams{}, WithBackend(NewFakeBackend()))
+
+	domain, _, err := client.Domains.CreateWithContext(context.Background(), Domain{Name: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, domain.Id
+}
+
+func TestFakeBackend_domains(t *testing.T) {
+	client, domainID := setupFakeClient(t)
+	ctx := context.Background()
+
+	_, _, err := client.Domains.CreateWithContext(ctx, Domain{Name: "example.com"})
+	if !errors.Is(err, ErrDomainExists) {
+		t.Errorf("got %v, want %v", err, ErrDomainExists)
+	}
+
+	domain, _, err := client.Domains.GetWithContext(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if domain.ID.String() != domainID {
+		t.Errorf("got %q, want %q", domain.ID, domainID)
+	}
+
+	_, err = client.Domains.DeleteWithContext(ctx, domainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	domains, _, err := client.Domains.ListWithContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domains) != 0 {
+		t.Errorf("got %+v, want no domains", domains)
+	}
+}
+
+func TestFakeBackend_records(t *testing.T) {
+	client, domainID := setupFakeClient(t)
+	ctx := context.Background()
+
+	for _, value := range []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"} {
+		_, _, err := client.Records.CreateWithContext(ctx, domainID, Record{Name: "www", Type: RecordTypeA, Value: value})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mx, _, err := client.Records.CreateWithContext(ctx, "example.com", Record{Type: RecordTypeMX, Value: "mx.example.com.", MX: "10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Record{ID: "5", Name: "@", Line: "默认", Type: RecordTypeMX, TTL: "600", Value: "mx.example.com.", MX: "10", Enabled: "1", Status: "enable"}
+	if !reflect.DeepEqual(mx, want) {
+		t.Errorf("got %+v, want %+v", mx, want)
+	}
+
+	// pages of one record.
+	var values []string
+	for it := client.Records.Iter(domainID, RecordListOptions{RecordType: RecordTypeA, Length: 1}); it.Next(ctx); {
+		values = append(values, it.Record().Value)
+	}
+
+	wantValues := []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}
+	if !reflect.DeepEqual(values, wantValues) {
+		t.Errorf("got %v, want %v", values, wantValues)
+	}
+
+	_, _, err = client.Records.UpdateWithContext(ctx, domainID, mx.ID, Record{TTL: "60"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, _, err := client.Records.GetWithContext(ctx, domainID, mx.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.TTL != "60" || updated.Value != "mx.example.com." {
+		t.Errorf("got %+v, want the TTL updated only", updated)
+	}
+
+	_, err = client.Records.DeleteWithContext(ctx, domainID, mx.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = client.Records.GetWithContext(ctx, domainID, mx.ID)
+	if !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("got %v, want %v", err, ErrRecordNotFound)
+	}
+}
+
+func TestFakeBackend_unsupported(t *testing.T) {
+	client, domainID := setupFakeClient(t)
+
+	_, _, err := client.Domains.Lock(context.Background(), domainID, 30)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("got %v, want %v", err, ErrUnsupported)
+	}
+}
+
+func TestFakeBackend_methods(t *testing.T) {
+	client, domainID := setupFakeClient(t)
+	ctx := context.Background()
+
+	lineID, err := client.LineResolver.ID(ctx, "example.com", "DP_Free", "Telecom")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lineID != "10=0" {
+		t.Errorf("got %q, want %q", lineID, "10=0")
+	}
+
+	record, _, err := client.Records.CreateWithContext(ctx, domainID, Record{Name: "home", Type: RecordTypeA, LineID: "10=0", Value: "1.1.1.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if record.Line != "电信" {
+		t.Errorf("got line %q, want the line name to be completed", record.Line)
+	}
+
+	_, _, err = client.Records.SetStatus(ctx, domainID, record.ID, RecordStatusDisable)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modified, _, err := client.Records.DDNS(ctx, domainID, record.ID, RecordDDNS{Name: "home", Line: "默认", Value: "2.2.2.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := RecordModify{ID: json.Number(record.ID), Name: "home", Value: "2.2.2.2", Status: string(RecordStatusDisable)}
+	if !reflect.DeepEqual(modified, want) {
+		t.Errorf("got %+v, want %+v", modified, want)
+	}
+
+	got, _, err := client.Records.GetWithContext(ctx, domainID, record.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Value != "2.2.2.2" || got.Enabled != "0" {
+		t.Errorf("got %+v, want the record to be updated", got)
+	}
+}
+
+func TestFakeBackend_listOptions(t *testing.T) {
+	client, domainID := setupFakeClient(t)
+	ctx := context.Background()
+
+	_, _, err := client.Records.CreateWithContext(ctx, domainID, Record{Name: "www", Type: RecordTypeA, Value: "1.1.1.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = client.Records.ListAll(ctx, domainID, RecordListOptions{Offset: -1})
+	if err == nil {
+		t.Error("an error was expected for a negative offset")
+	}
+
+	_, _, err = client.Domains.ListAll(ctx, DomainListOptions{Offset: -1})
+	if err == nil {
+		t.Error("an error was expected for a negative offset")
+	}
+
+	domains, _, err := client.Domains.ListAll(ctx, DomainListOptions{Type: "mine"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domains) != 1 {
+		t.Errorf("got %+v, want 1 domain", domains)
+	}
+
+	domains, _, err = client.Domains.ListAll(ctx, DomainListOptions{Type: "share"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domains) != 0 {
+		t.Errorf("got %+v, want no shared domain", domains)
+	}
+
+	_, _, err = client.Domains.ListAll(ctx, DomainListOptions{Type: "vip"})
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("got %v, want %v", err, ErrUnsupported)
+	}
+}
@@ -0,0 +1,343 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// lineAliases maps the English names of the lines, used by the international API, to their Chinese names.
+var lineAliases = map[string]string{
+	"default":       "默认",
+	"telecom":       "电信",
+	"unicom":        "联通",
+	"mobile":        "移动",
+	"edu":           "教育网",
+	"education":     "教育网",
+	"cernet":        "教育网",
+	"tietong":       "铁通",
+	"drpeng":        "鹏博士",
+	"greatwall":     "长城宽带",
+	"overseas":      "境外",
+	"abroad":        "境外",
+	"domestic":      "国内",
+	"search engine": "搜索引擎",
+	"baidu":         "百度",
+	"google":        "谷歌",
+	"youdao":        "有道",
+	"bing":          "必应",
+	"sogou":         "搜狗",
+	"qihu":          "奇虎",
+	"soso":          "搜搜",
+}
+
+// internationalLineNames maps the Chinese names of the lines to their English names, used by the international API.
+var internationalLineNames = reverseLineAliases(lineAliases)
+
+// reverseLineAliases maps the Chinese names of the lines to one of their English aliases:
+// the shortest one, then the first in alphabetical order, so the choice doesn't depend on the map iteration.
+func reverseLineAliases(aliases map[string]string) map[string]string {
+	names := make(map[string]string, len(aliases))
+
+	for english, chinese := range aliases {
+		current, ok := names[chinese]
+		if !ok || len(english) < len(current) || len(english) == len(current) && english < current {
+			names[chinese] = english
+		}
+	}
+
+	return names
+}
+
+// lineTable is the lines of a domain, indexed by name and by id.
+type lineTable struct {
+	byName map[string]string
+	byID   map[string]string
+}
+
+func newLineTable(lines []Line) lineTable {
+	table := lineTable{byName: make(map[string]string), byID: make(map[string]string)}
+
+	table.add(lines...)
+
+	return table
+}
+
+func (t lineTable) add(lines ...Line) {
+	for _, line := range lines {
+		t.byName[line.LineName] = line.LineId
+
+		if line.LineId != "" {
+			t.byID[line.LineId] = line.LineName
+		}
+	}
+}
+
+// id returns the id of the line, matching its name exactly, case-insensitively, or through its alias.
+func (t lineTable) id(name string) (string, bool) {
+	if id, ok := t.byName[name]; ok {
+		return id, true
+	}
+
+	for _, candidate := range lineNameCandidates(name) {
+		for lineName, id := range t.byName {
+			if strings.EqualFold(lineName, candidate) {
+				return id, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// lineNameCandidates returns the name and its aliases, in English and in Chinese.
+func lineNameCandidates(name string) []string {
+	candidates := []string{name}
+
+	if chinese, ok := lineAliases[strings.ToLower(name)]; ok {
+		candidates = append(candidates, chinese)
+	}
+
+	for english, chinese := range lineAliases {
+		if chinese == name {
+			candidates = append(candidates, english)
+		}
+	}
+
+	return candidates
+}
+
+// domainLineKey identifies the lines of a domain.
+type domainLineKey struct {
+	domain string
+	grade  string
+}
+
+// LineResolver resolves the names of the lines (e.g. "默认", "电信", or their English aliases) to their ids, and back.
+// The lines are cached per grade (per domain and grade with the international API),
+// the custom lines and line groups per domain id, or per domain name if its id isn't known.
+// The custom lines and line groups listed by the LinesService are resolved too.
+// It is safe for concurrent use.
+type LineResolver struct {
+	client *Client
+
+	mu     sync.Mutex
+	lines  map[domainLineKey]lineTable
+	custom map[string]lineTable
+
+	domainIDs map[string]string
+}
+
+func newLineResolver(client *Client) *LineResolver {
+	return &LineResolver{
+		client: client,
+		lines:  make(map[domainLineKey]lineTable),
+		custom: make(map[string]lineTable),
+
+		domainIDs: make(map[string]string),
+	}
+}
+
+// ID returns the id of the line of a domain.
+// The domain name is only required by the international API.
+func (r *LineResolver) ID(ctx context.Context, domain, grade, name string) (string, error) {
+	if id, ok := r.customTable(domain).byName[name]; ok && id != "" {
+		return id, nil
+	}
+
+	table, err := r.table(ctx, domain, grade)
+	if err != nil {
+		return "", err
+	}
+
+	id, ok := table.id(name)
+	if !ok {
+		return "", fmt.Errorf("%w: %s (grade %s)", ErrLineNotFound, name, grade)
+	}
+
+	return id, nil
+}
+
+// Name returns the name of the line of a domain.
+// The domain name is only required by the international API.
+func (r *LineResolver) Name(ctx context.Context, domain, grade, id string) (string, error) {
+	if name, ok := r.customTable(domain).byID[id]; ok {
+		return name, nil
+	}
+
+	table, err := r.table(ctx, domain, grade)
+	if err != nil {
+		return "", err
+	}
+
+	name, ok := table.byID[id]
+	if !ok {
+		return "", fmt.Errorf("%w: id %s (grade %s)", ErrLineNotFound, id, grade)
+	}
+
+	return name, nil
+}
+
+// Reset clears the cache.
+func (r *LineResolver) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lines = make(map[domainLineKey]lineTable)
+	r.custom = make(map[string]lineTable)
+	r.domainIDs = make(map[string]string)
+}
+
+// register adds the custom lines, or line groups, of a domain (name or id).
+// They are cached by domain id if the id of the domain name is known, by domain name otherwise.
+func (r *LineResolver) register(domain string, lines ...Line) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := r.domainKeys(domain)[0]
+
+	table, ok := r.custom[key]
+	if !ok {
+		table = newLineTable(nil)
+		r.custom[key] = table
+	}
+
+	table.add(lines...)
+}
+
+// learn records the ids of domain names, so that their custom lines are found by name or by id.
+func (r *LineResolver) learn(domains ...Domain) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, domain := range domains {
+		if domain.Name != "" && domain.ID != "" {
+			r.domainIDs[strings.ToLower(domain.Name)] = domain.ID.String()
+		}
+	}
+}
+
+// forget removes the custom lines, and line groups, cached for a domain (name or id),
+// so that they are registered again by the next listing.
+func (r *LineResolver) forget(domain string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range r.domainKeys(domain) {
+		delete(r.custom, key)
+	}
+}
+
+// customTable returns a copy of the custom lines registered for a domain (name or id).
+func (r *LineResolver) customTable(domain string) lineTable {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	merged := newLineTable(nil)
+	for _, key := range r.domainKeys(domain) {
+		for name, id := range r.custom[key].byName {
+			merged.add(Line{LineName: name, LineId: id})
+		}
+	}
+
+	return merged
+}
+
+// domainKeys returns the keys of the custom lines of a domain (name or id): its id first if known, then its names.
+// r.mu must be held.
+func (r *LineResolver) domainKeys(domain string) []string {
+	domain = strings.ToLower(domain)
+
+	if !strings.Contains(domain, ".") {
+		keys := []string{domain}
+		for name, id := range r.domainIDs {
+			if id == domain {
+				keys = append(keys, name)
+			}
+		}
+
+		return keys
+	}
+
+	if id, ok := r.domainIDs[domain]; ok {
+		return []string{id, domain}
+	}
+
+	return []string{domain}
+}
+
+// complete sets the line name, or the line id, of a record of a domain when only the other one is set.
+// Only the lines already cached are used, so no request is sent: the API accepts either of them,
+// and the record is sent as given when its line isn't cached.
+// The English aliases of the line names are translated for the Chinese API.
+func (r *LineResolver) complete(domainID string, record *Record) {
+	if record.Line != "" && !r.client.CommonParams.IsInternational {
+		if chinese, ok := lineAliases[strings.ToLower(record.Line)]; ok {
+			record.Line = chinese
+		}
+	}
+
+	switch {
+	case record.Line == "" && record.LineID != "":
+		if name, ok := r.cachedTable(domainID).byID[record.LineID]; ok {
+			record.Line = name
+		}
+	case record.Line != "" && record.LineID == "":
+		if id, ok := r.cachedTable(domainID).id(record.Line); ok {
+			record.LineID = id
+		}
+	}
+}
+
+// cachedTable returns a copy of the lines cached for a domain: its custom lines, and its lines
+// (with the Chinese API, the lines of all the grades, their ids not depending on the grade).
+func (r *LineResolver) cachedTable(domain string) lineTable {
+	merged := r.customTable(domain)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, table := range r.lines {
+		if key.domain != "" && key.domain != domain {
+			continue
+		}
+
+		for name, id := range table.byName {
+			if _, ok := merged.byName[name]; !ok {
+				merged.add(Line{LineName: name, LineId: id})
+			}
+		}
+	}
+
+	return merged
+}
+
+func (r *LineResolver) table(ctx context.Context, domain, grade string) (lineTable, error) {
+	key := domainLineKey{grade: grade}
+	if r.client.CommonParams.IsInternational {
+		// the lines only depend on the grade with the Chinese API.
+		key.domain = domain
+	}
+
+	r.mu.Lock()
+	table, ok := r.lines[key]
+	r.mu.Unlock()
+
+	if ok {
+		return table, nil
+	}
+
+	lines, _, err := r.client.Domains.GetLinesWithContext(ctx, domain, grade)
+	if err != nil {
+		return lineTable{}, err
+	}
+
+	table = newLineTable(lines)
+
+	r.mu.Lock()
+	r.lines[key] = table
+	r.mu.Unlock()
+
+	return table, nil
+}
@@ -0,0 +1,136 @@
+package dnspod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestLineResolver(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/Record.Line", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"line_ids":{"默认":0,"电信":"10=0","联通":"10=1"}}`)
+	})
+
+	ctx := context.Background()
+
+	testCases := []struct {
+		name string
+		want string
+	}{
+		{name: "默认", want: "0"},
+		{name: "电信", want: "10=0"},
+		{name: "Unicom", want: "10=1"},
+	}
+
+	for _, test := range testCases {
+		id, err := client.LineResolver.ID(ctx, "example.com", "DP_Free", test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if id != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, id, test.want)
+		}
+	}
+
+	name, err := client.LineResolver.Name(ctx, "example.com", "DP_Free", "10=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "联通" {
+		t.Errorf("got %v, want %v", name, "联通")
+	}
+
+	_, err = client.LineResolver.ID(ctx, "example.com", "DP_Free", "移动")
+	if !errors.Is(err, ErrLineNotFound) {
+		t.Errorf("got %v, want %v", err, ErrLineNotFound)
+	}
+
+	if calls != 1 {
+		t.Errorf("got %d calls, want the lines to be cached after 1 call", calls)
+	}
+}
+
+func TestRecordsService_CreateRecord_lineID(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Domain.Info", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the domain should not be fetched to complete the line")
+	})
+
+	lineCalls := 0
+	mux.HandleFunc("/Record.Line", func(w http.ResponseWriter, r *http.Request) {
+		lineCalls++
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"line_ids":{"默认":0,"电信":"10=0"}}`)
+	})
+
+	var lines [][2]string
+	mux.HandleFunc("/Record.Create", func(w http.ResponseWriter, r *http.Request) {
+		lines = append(lines, [2]string{r.FormValue("record_line"), r.FormValue("record_line_id")})
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"record":{"id":"26954449", "name":"@", "status":"enable"}}`)
+	})
+
+	ctx := context.Background()
+
+	// not cached: sent as given.
+	_, _, err := client.Records.CreateWithContext(ctx, "44146112", Record{Name: "@", Type: RecordTypeA, LineID: "10=0", Value: "1.1.1.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.LineResolver.ID(ctx, "example.com", "DP_Free", "默认")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// cached: completed.
+	for _, record := range []Record{
+		{Name: "@", Type: RecordTypeA, LineID: "10=0", Value: "1.1.1.1"},
+		{Name: "@", Type: RecordTypeA, Line: "Telecom", Value: "1.1.1.1"},
+		{Name: "@", Type: RecordTypeA, LineID: "10=99", Value: "1.1.1.1"},
+	} {
+		_, _, err = client.Records.CreateWithContext(ctx, "44146112", record)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := [][2]string{{"", "10=0"}, {"电信", "10=0"}, {"电信", "10=0"}, {"", "10=99"}}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("got %+v, want %+v", lines, want)
+	}
+
+	if lineCalls != 1 {
+		t.Errorf("got %d calls, want 1", lineCalls)
+	}
+}
+
+func TestInternationalLineNames(t *testing.T) {
+	for english, chinese := range lineAliases {
+		name, ok := internationalLineNames[chinese]
+		if !ok {
+			t.Errorf("%s: no English name for %s", english, chinese)
+			continue
+		}
+
+		if lineAliases[name] != chinese {
+			t.Errorf("%s: got %s, which is an alias of %s", chinese, name, lineAliases[name])
+		}
+	}
+
+	for chinese, english := range map[string]string{"教育网": "edu", "境外": "abroad", "搜索引擎": "search engine"} {
+		if got := internationalLineNames[chinese]; got != english {
+			t.Errorf("%s: got %s, want %s", chinese, got, english)
+		}
+	}
+}
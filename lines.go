@@ -0,0 +1,342 @@
+package dnspod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+const (
+	methodCustomLineList   = "Custom.Line.List"
+	methodCustomLineCreate = "Custom.Line.Create"
+	methodCustomLineModify = "Custom.Line.Modify"
+	methodCustomLineRemove = "Custom.Line.Remove"
+	methodLineGroupList    = "Line.Group.List"
+	methodLineGroupCreate  = "Line.Group.Create"
+	methodLineGroupModify  = "Line.Group.Modify"
+	methodLineGroupRemove  = "Line.Group.Remove"
+)
+
+// maxCustomLineRanges is the maximum number of IP ranges of a custom line.
+const maxCustomLineRanges = 50
+
+// CustomLine is a resolution line defined by IP ranges.
+type CustomLine struct {
+	ID     string
+	Name   string
+	Ranges []netip.Prefix
+}
+
+type customLineJSON struct {
+	ID   json.Number `json:"id,omitempty"`
+	Name string      `json:"name"`
+	Area string      `json:"area"`
+}
+
+// UnmarshalJSON handles the deserialization of the IP ranges, a comma separated list of CIDRs or addresses.
+func (l *CustomLine) UnmarshalJSON(data []byte) error {
+	raw := customLineJSON{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	ranges, err := parseIPRanges(raw.Area)
+	if err != nil {
+		return err
+	}
+
+	*l = CustomLine{ID: raw.ID.String(), Name: raw.Name, Ranges: ranges}
+
+	return nil
+}
+
+// Line returns the custom line as a line usable by the records.
+func (l CustomLine) Line() Line {
+	return Line{LineName: l.Name, LineId: l.ID}
+}
+
+// Validate checks the custom line before submitting it to the API.
+func (l CustomLine) Validate() error {
+	if l.Name == "" {
+		return fmt.Errorf("custom line: name is required")
+	}
+
+	if len(l.Ranges) == 0 || len(l.Ranges) > maxCustomLineRanges {
+		return fmt.Errorf("custom line %s: between 1 and %d IP ranges are required, got %d", l.Name, maxCustomLineRanges, len(l.Ranges))
+	}
+
+	for _, prefix := range l.Ranges {
+		if !prefix.IsValid() {
+			return fmt.Errorf("custom line %s: invalid IP range %s", l.Name, prefix)
+		}
+
+		if prefix != prefix.Masked() {
+			return fmt.Errorf("custom line %s: IP range %s has host bits set, use %s", l.Name, prefix, prefix.Masked())
+		}
+	}
+
+	return nil
+}
+
+func (l CustomLine) area() string {
+	ranges := make([]string, 0, len(l.Ranges))
+	for _, prefix := range l.Ranges {
+		ranges = append(ranges, prefix.String())
+	}
+
+	return strings.Join(ranges, ",")
+}
+
+// ParseCustomLine returns a custom line from IP ranges given as CIDRs (e.g. "10.0.0.0/8") or addresses.
+func ParseCustomLine(name string, ranges ...string) (CustomLine, error) {
+	prefixes, err := parseIPRanges(strings.Join(ranges, ","))
+	if err != nil {
+		return CustomLine{}, err
+	}
+
+	line := CustomLine{Name: name, Ranges: prefixes}
+
+	return line, line.Validate()
+}
+
+func parseIPRanges(area string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+
+	for _, value := range strings.Split(area, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if !strings.Contains(value, "/") {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid IP range %q: %w", value, err)
+			}
+
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP range %q: %w", value, err)
+		}
+
+		prefixes = append(prefixes, prefix)
+	}
+
+	return prefixes, nil
+}
+
+// LineGroup is a group of lines, usable as a single line by the records.
+type LineGroup struct {
+	ID    string
+	Name  string
+	Lines []string
+}
+
+type lineGroupJSON struct {
+	ID    json.Number `json:"id,omitempty"`
+	Name  string      `json:"name"`
+	Lines string      `json:"lines"`
+}
+
+// UnmarshalJSON handles the deserialization of the lines, a comma separated list.
+func (g *LineGroup) UnmarshalJSON(data []byte) error {
+	raw := lineGroupJSON{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*g = LineGroup{ID: raw.ID.String(), Name: raw.Name}
+
+	for _, line := range strings.Split(raw.Lines, ",") {
+		if line = strings.TrimSpace(line); line != "" {
+			g.Lines = append(g.Lines, line)
+		}
+	}
+
+	return nil
+}
+
+// Line returns the line group as a line usable by the records.
+func (g LineGroup) Line() Line {
+	return Line{LineName: g.Name, LineId: g.ID}
+}
+
+type customLineListWrapper struct {
+	Lines []CustomLine `json:"lines"`
+}
+
+type lineGroupListWrapper struct {
+	LineGroups []LineGroup `json:"line_groups"`
+}
+
+// LinesService handles communication with the custom lines and line groups related methods of the DNSPod API.
+// They are only available with the paid plans.
+//
+// The lines listed by the service are registered in the LineResolver of the client,
+// and forgotten by it when the lines of the domain are modified.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/lines.html
+type LinesService struct {
+	client *Client
+}
+
+// ListCustom lists the custom lines of a domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/lines.html#custom-line-list
+func (s *LinesService) ListCustom(ctx context.Context, domainID string) ([]CustomLine, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+
+	returnedLines := customLineListWrapper{}
+
+	res, err := s.client.post(ctx, methodCustomLineList, payload, &returnedLines)
+	if err != nil {
+		return nil, res, err
+	}
+
+	lines := make([]Line, 0, len(returnedLines.Lines))
+	for _, line := range returnedLines.Lines {
+		lines = append(lines, line.Line())
+	}
+
+	s.client.LineResolver.register(domainID, lines...)
+
+	return returnedLines.Lines, res, nil
+}
+
+// CreateCustom creates a custom line, validated before being sent.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/lines.html#custom-line-create
+func (s *LinesService) CreateCustom(ctx context.Context, domainID string, line CustomLine) (*Response, error) {
+	if err := line.Validate(); err != nil {
+		return nil, err
+	}
+
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("name", line.Name)
+	payload.Set("area", line.area())
+
+	return s.write(ctx, domainID, methodCustomLineCreate, payload)
+}
+
+// UpdateCustom replaces the custom line named name, validated before being sent.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/lines.html#custom-line-modify
+func (s *LinesService) UpdateCustom(ctx context.Context, domainID, name string, line CustomLine) (*Response, error) {
+	if err := line.Validate(); err != nil {
+		return nil, err
+	}
+
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("name", name)
+	payload.Set("new_name", line.Name)
+	payload.Set("area", line.area())
+
+	return s.write(ctx, domainID, methodCustomLineModify, payload)
+}
+
+// DeleteCustom deletes a custom line.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/lines.html#custom-line-remove
+func (s *LinesService) DeleteCustom(ctx context.Context, domainID, name string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("name", name)
+
+	return s.write(ctx, domainID, methodCustomLineRemove, payload)
+}
+
+// ListGroups lists the line groups of a domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/lines.html#line-group-list
+func (s *LinesService) ListGroups(ctx context.Context, domainID string) ([]LineGroup, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+
+	returnedGroups := lineGroupListWrapper{}
+
+	res, err := s.client.post(ctx, methodLineGroupList, payload, &returnedGroups)
+	if err != nil {
+		return nil, res, err
+	}
+
+	lines := make([]Line, 0, len(returnedGroups.LineGroups))
+	for _, group := range returnedGroups.LineGroups {
+		lines = append(lines, group.Line())
+	}
+
+	s.client.LineResolver.register(domainID, lines...)
+
+	return returnedGroups.LineGroups, res, nil
+}
+
+// CreateGroup creates a line group.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/lines.html#line-group-create
+func (s *LinesService) CreateGroup(ctx context.Context, domainID string, group LineGroup) (*Response, error) {
+	if group.Name == "" || len(group.Lines) == 0 {
+		return nil, fmt.Errorf("line group: name and lines are required")
+	}
+
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("name", group.Name)
+	payload.Set("lines", strings.Join(group.Lines, ","))
+
+	return s.write(ctx, domainID, methodLineGroupCreate, payload)
+}
+
+// UpdateGroup replaces the line group named name.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/lines.html#line-group-modify
+func (s *LinesService) UpdateGroup(ctx context.Context, domainID, name string, group LineGroup) (*Response, error) {
+	if group.Name == "" || len(group.Lines) == 0 {
+		return nil, fmt.Errorf("line group: name and lines are required")
+	}
+
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("name", name)
+	payload.Set("new_name", group.Name)
+	payload.Set("lines", strings.Join(group.Lines, ","))
+
+	return s.write(ctx, domainID, methodLineGroupModify, payload)
+}
+
+// DeleteGroup deletes a line group.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/lines.html#line-group-remove
+func (s *LinesService) DeleteGroup(ctx context.Context, domainID, name string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("name", name)
+
+	return s.write(ctx, domainID, methodLineGroupRemove, payload)
+}
+
+// write sends a modification of the custom lines or line groups of a domain,
+// and removes them from the LineResolver of the client.
+func (s *LinesService) write(ctx context.Context, domainID, method string, payload url.Values) (*Response, error) {
+	defer s.client.LineResolver.forget(domainID)
+
+	return s.client.post(ctx, method, payload, nil)
+}
@@ -0,0 +1,161 @@
+package dnspod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestParseCustomLine(t *testing.T) {
+	line, err := ParseCustomLine("office", "10.0.0.0/8", "192.168.1.1", "2001:db8::/32")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.1/32"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+	if !reflect.DeepEqual(line.Ranges, want) {
+		t.Errorf("got %v, want %v", line.Ranges, want)
+	}
+
+	for _, ranges := range [][]string{{"10.0.0.1/8"}, {"10.0.0.0/33"}, {"not an IP"}, {}} {
+		if _, err := ParseCustomLine("office", ranges...); err == nil {
+			t.Errorf("%v: an error was expected", ranges)
+		}
+	}
+}
+
+func TestLinesService_CreateCustom(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Custom.Line.Create", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("name") != "office" || r.FormValue("area") != "10.0.0.0/8,192.168.1.1/32" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
+	})
+
+	line, err := ParseCustomLine("office", "10.0.0.0/8", "192.168.1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Lines.CreateCustom(context.Background(), "2059079", line)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLinesService_ListCustom(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Custom.Line.List", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"lines":[{"id":"1001","name":"office","area":"10.0.0.0/8,192.168.1.1"}]}`)
+	})
+
+	infoCalls := 0
+	mux.HandleFunc("/Domain.Info", func(w http.ResponseWriter, r *http.Request) {
+		infoCalls++
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"domain": {"id":2059079, "name":"example.com", "grade":"DP_Extra"}}`)
+	})
+
+	lines, _, err := client.Lines.ListCustom(context.Background(), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if infoCalls != 0 {
+		t.Errorf("got %d calls of Domain.Info, want none", infoCalls)
+	}
+
+	want := []CustomLine{{
+		ID:     "1001",
+		Name:   "office",
+		Ranges: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("192.168.1.1/32")},
+	}}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("got %+v, want %+v", lines, want)
+	}
+
+	// the custom lines are resolved without fetching the lines of the grade,
+	// by domain name, or by domain id once the domain has been fetched.
+	_, _, err = client.Domains.GetWithContext(context.Background(), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, domain := range []string{"example.com", "2059079"} {
+		id, err := client.LineResolver.ID(context.Background(), domain, "DP_Extra", "office")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if id != "1001" {
+			t.Errorf("%s: got %v, want %v", domain, id, "1001")
+		}
+	}
+}
+
+func TestLinesService_DeleteCustom_forget(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Custom.Line.List", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"lines":[{"id":"1001","name":"office","area":"10.0.0.0/8"}]}`)
+	})
+
+	mux.HandleFunc("/Custom.Line.Remove", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
+	})
+
+	mux.HandleFunc("/Record.Line", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"line_ids":{"默认":0}}`)
+	})
+
+	ctx := context.Background()
+
+	_, _, err := client.Lines.ListCustom(ctx, "2059079")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Lines.DeleteCustom(ctx, "2059079", "office")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.LineResolver.ID(ctx, "2059079", "DP_Extra", "office")
+	if !errors.Is(err, ErrLineNotFound) {
+		t.Errorf("got %v, want %v", err, ErrLineNotFound)
+	}
+}
+
+func TestLinesService_ListGroups(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Line.Group.List", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"line_groups":[{"id":"2001","name":"isp","lines":"电信,联通"}]}`)
+	})
+
+	groups, _, err := client.Lines.ListGroups(context.Background(), "2059079")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []LineGroup{{ID: "2001", Name: "isp", Lines: []string{"电信", "联通"}}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("got %+v, want %+v", groups, want)
+	}
+}
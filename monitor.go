@@ -0,0 +1,443 @@
+package dnspod
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	methodMonitorListSubDomain = "Monitor.Listsubdomain"
+	methodMonitorListSubValue  = "Monitor.Listsubvalue"
+	methodMonitorListDomain    = "Monitor.Listdomain"
+	methodMonitorCreate        = "Monitor.Create"
+	methodMonitorModify        = "Monitor.Modify"
+	methodMonitorRemove        = "Monitor.Remove"
+	methodMonitorInfo          = "Monitor.Info"
+	methodMonitorSetStatus     = "Monitor.Setstatus"
+	methodMonitorGetHistory    = "Monitor.Gethistory"
+	methodMonitorUserDesc      = "Monitor.Userdesc"
+	methodMonitorGetDowns      = "Monitor.Getdowns"
+)
+
+// MonitorType is the protocol used to check a record.
+type MonitorType string
+
+// Monitor types.
+const (
+	MonitorTypeHTTP  MonitorType = "http"
+	MonitorTypeHTTPS MonitorType = "https"
+)
+
+// MonitorFailover is the action taken when a monitored record is down.
+type MonitorFailover string
+
+// Monitor failover strategies.
+const (
+	MonitorFailoverNone  MonitorFailover = "pass"        // only notify
+	MonitorFailoverPause MonitorFailover = "pass_paused" // pause the record
+	MonitorFailoverAuto  MonitorFailover = "auto"        // switch to the other records of the same sub domain
+)
+
+// MonitorStatus is the status of a monitor.
+type MonitorStatus string
+
+// Monitor statuses.
+const (
+	MonitorStatusEnabled  MonitorStatus = "enabled"
+	MonitorStatusDisabled MonitorStatus = "disabled"
+)
+
+// MonitorConfig is the configuration of a monitor.
+type MonitorConfig struct {
+	Type MonitorType
+	Host string // Host header of the requests
+	Path string
+	Port int
+
+	// ExpectedCodes are the HTTP status codes of a healthy record, e.g. 200 and 301.
+	// The API default is used if empty.
+	ExpectedCodes []int
+
+	// Interval between two checks, in seconds.
+	Interval int
+
+	// Points are the monitoring points, e.g. "dx,lt,yd".
+	Points string
+
+	Failover MonitorFailover
+
+	// BackupIPs replace the IP of the record when it is down, instead of the failover strategy.
+	BackupIPs []string
+
+	// SMSNotice and EmailNotice are the recipients of the notifications: "me", "share" or "me,share".
+	SMSNotice   string
+	EmailNotice string
+
+	// LessNotice limits the notifications to one per incident.
+	LessNotice bool
+
+	CallbackURL string
+	CallbackKey string
+}
+
+func (c MonitorConfig) setPayload(payload url.Values) {
+	payload.Set("monitor_type", string(c.Type))
+	payload.Set("monitor_path", c.Path)
+	payload.Set("host", c.Host)
+
+	if c.Port > 0 {
+		payload.Set("port", strconv.Itoa(c.Port))
+	}
+
+	if len(c.ExpectedCodes) > 0 {
+		codes := make([]string, 0, len(c.ExpectedCodes))
+		for _, code := range c.ExpectedCodes {
+			codes = append(codes, strconv.Itoa(code))
+		}
+
+		payload.Set("status_code", strings.Join(codes, ","))
+	}
+
+	if c.Interval > 0 {
+		payload.Set("monitor_interval", strconv.Itoa(c.Interval))
+	}
+
+	if c.Points != "" {
+		payload.Set("points", c.Points)
+	}
+
+	if len(c.BackupIPs) > 0 {
+		payload.Set("bak_ip", strings.Join(c.BackupIPs, ","))
+	} else if c.Failover != "" {
+		payload.Set("bak_ip", string(c.Failover))
+	}
+
+	if c.SMSNotice != "" {
+		payload.Set("sms_notice", c.SMSNotice)
+	}
+
+	if c.EmailNotice != "" {
+		payload.Set("email_notice", c.EmailNotice)
+	}
+
+	payload.Set("less_notice", yesNo(c.LessNotice))
+
+	if c.CallbackURL != "" {
+		payload.Set("callback_url", c.CallbackURL)
+		payload.Set("callback_key", c.CallbackKey)
+	}
+}
+
+// Monitor is a health check of a record.
+type Monitor struct {
+	MonitorID       string        `json:"monitor_id,omitempty"`
+	DomainID        json.Number   `json:"domain_id,omitempty"`
+	Domain          string        `json:"domain,omitempty"`
+	RecordID        json.Number   `json:"record_id,omitempty"`
+	SubDomain       string        `json:"sub_domain,omitempty"`
+	RecordLine      string        `json:"record_line,omitempty"`
+	IP              string        `json:"ip,omitempty"`
+	Host            string        `json:"host,omitempty"`
+	Port            json.Number   `json:"port,omitempty"`
+	MonitorType     MonitorType   `json:"monitor_type,omitempty"`
+	MonitorPath     string        `json:"monitor_path,omitempty"`
+	MonitorInterval json.Number   `json:"monitor_interval,omitempty"`
+	Points          string        `json:"points,omitempty"`
+	BakIP           string        `json:"bak_ip,omitempty"`
+	Status          MonitorStatus `json:"status,omitempty"`
+	Now             string        `json:"now,omitempty"` // current state of the record: ok or warn
+	SMSNotice       string        `json:"sms_notice,omitempty"`
+	EmailNotice     string        `json:"email_notice,omitempty"`
+	LessNotice      string        `json:"less_notice,omitempty"`
+	CallbackURL     string        `json:"callback_url,omitempty"`
+	CallbackKey     string        `json:"callback_key,omitempty"`
+	CreatedOn       string        `json:"created_on,omitempty"`
+	UpdatedOn       string        `json:"updated_on,omitempty"`
+}
+
+// MonitorPoint is a record which can be monitored.
+type MonitorPoint struct {
+	RecordID   json.Number `json:"record_id,omitempty"`
+	Value      string      `json:"value,omitempty"`
+	RecordLine string      `json:"record_line,omitempty"`
+}
+
+// MonitorDomain is a domain with monitors.
+type MonitorDomain struct {
+	DomainID json.Number `json:"domain_id,omitempty"`
+	Domain   string      `json:"domain,omitempty"`
+	Count    json.Number `json:"count,omitempty"`
+}
+
+// MonitorHistory is the result of a check.
+type MonitorHistory struct {
+	CreatedAt    string      `json:"created_at,omitempty"`
+	IP           string      `json:"ip,omitempty"`
+	Status       string      `json:"status,omitempty"` // ok or warn
+	ResponseTime json.Number `json:"response_time,omitempty"`
+	StatusCode   json.Number `json:"status_code,omitempty"`
+}
+
+// MonitorDown is an incident detected by a monitor.
+type MonitorDown struct {
+	MonitorID  string `json:"monitor_id,omitempty"`
+	Domain     string `json:"domain,omitempty"`
+	SubDomain  string `json:"sub_domain,omitempty"`
+	RecordLine string `json:"record_line,omitempty"`
+	IP         string `json:"ip,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	WarnReason string `json:"warn_reason,omitempty"`
+	SwitchLog  string `json:"switch_log,omitempty"`
+}
+
+// MonitorUserDesc is the monitoring quota of the account.
+type MonitorUserDesc struct {
+	MaxMonitors     json.Number `json:"max_monitors,omitempty"`
+	CurrentMonitors json.Number `json:"current_monitors,omitempty"`
+	MinInterval     json.Number `json:"min_interval,omitempty"`
+	SMSBalance      json.Number `json:"sms_balance,omitempty"`
+}
+
+// MonitorDownOptions is the pagination of the incidents.
+type MonitorDownOptions struct {
+	Offset int
+	Length int
+}
+
+type monitorSubDomainWrapper struct {
+	SubDomains []string `json:"subdomain"`
+}
+
+type monitorPointsWrapper struct {
+	Points struct {
+		List []MonitorPoint `json:"list"`
+	} `json:"points"`
+}
+
+type monitorDomainsWrapper struct {
+	Domains []MonitorDomain `json:"domains"`
+}
+
+type monitorCreateWrapper struct {
+	Monitor struct {
+		MonitorID string `json:"monitor_id"`
+	} `json:"monitor"`
+}
+
+type monitorInfoWrapper struct {
+	Info Monitor `json:"info"`
+}
+
+type monitorHistoryWrapper struct {
+	History []MonitorHistory `json:"monitor_history"`
+}
+
+type monitorUserDescWrapper struct {
+	Desc MonitorUserDesc `json:"desc"`
+}
+
+type monitorDownsWrapper struct {
+	Downs []MonitorDown `json:"monitor_downs"`
+}
+
+// MonitorService handles communication with the D-Monitor (D监控) related methods of the DNSPod API.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html
+type MonitorService struct {
+	client *Client
+}
+
+// ListSubDomains lists the sub domains of a domain which can be monitored.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html#monitor-listsubdomain
+func (s *MonitorService) ListSubDomains(ctx context.Context, domainID string) ([]string, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+
+	returnedSubDomains := monitorSubDomainWrapper{}
+
+	res, err := s.client.post(ctx, methodMonitorListSubDomain, payload, &returnedSubDomains)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedSubDomains.SubDomains, res, nil
+}
+
+// ListSubValues lists the records of a sub domain which can be monitored.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html#monitor-listsubvalue
+func (s *MonitorService) ListSubValues(ctx context.Context, domainID, subDomain string) ([]MonitorPoint, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("subdomain", subDomain)
+
+	returnedPoints := monitorPointsWrapper{}
+
+	res, err := s.client.post(ctx, methodMonitorListSubValue, payload, &returnedPoints)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedPoints.Points.List, res, nil
+}
+
+// ListDomains lists the domains with monitors.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html#monitor-listdomain
+func (s *MonitorService) ListDomains(ctx context.Context) ([]MonitorDomain, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+
+	returnedDomains := monitorDomainsWrapper{}
+
+	res, err := s.client.post(ctx, methodMonitorListDomain, payload, &returnedDomains)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedDomains.Domains, res, nil
+}
+
+// Create creates a monitor of a record, and returns its id.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html#monitor-create
+func (s *MonitorService) Create(ctx context.Context, domainID, recordID string, config MonitorConfig) (string, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("record_id", recordID)
+	config.setPayload(payload)
+
+	returnedMonitor := monitorCreateWrapper{}
+
+	res, err := s.client.post(ctx, methodMonitorCreate, payload, &returnedMonitor)
+	if err != nil {
+		return "", res, err
+	}
+
+	return returnedMonitor.Monitor.MonitorID, res, nil
+}
+
+// Update replaces the configuration of a monitor.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html#monitor-modify
+func (s *MonitorService) Update(ctx context.Context, monitorID string, config MonitorConfig) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("monitor_id", monitorID)
+	config.setPayload(payload)
+
+	return s.client.post(ctx, methodMonitorModify, payload, nil)
+}
+
+// Delete deletes a monitor.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html#monitor-remove
+func (s *MonitorService) Delete(ctx context.Context, monitorID string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("monitor_id", monitorID)
+
+	return s.client.post(ctx, methodMonitorRemove, payload, nil)
+}
+
+// Get fetches a monitor.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html#monitor-info
+func (s *MonitorService) Get(ctx context.Context, monitorID string) (Monitor, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("monitor_id", monitorID)
+
+	returnedMonitor := monitorInfoWrapper{}
+
+	res, err := s.client.post(ctx, methodMonitorInfo, payload, &returnedMonitor)
+	if err != nil {
+		return Monitor{}, res, err
+	}
+
+	return returnedMonitor.Info, res, nil
+}
+
+// SetStatus enables or disables a monitor.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html#monitor-setstatus
+func (s *MonitorService) SetStatus(ctx context.Context, monitorID string, status MonitorStatus) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("monitor_id", monitorID)
+	payload.Set("status", string(status))
+
+	return s.client.post(ctx, methodMonitorSetStatus, payload, nil)
+}
+
+// History fetches the results of the checks of a monitor, over the last hours.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html#monitor-gethistory
+func (s *MonitorService) History(ctx context.Context, monitorID string, hours int) ([]MonitorHistory, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("monitor_id", monitorID)
+
+	if hours > 0 {
+		payload.Set("hours", strconv.Itoa(hours))
+	}
+
+	returnedHistory := monitorHistoryWrapper{}
+
+	res, err := s.client.post(ctx, methodMonitorGetHistory, payload, &returnedHistory)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedHistory.History, res, nil
+}
+
+// UserDesc fetches the monitoring quota of the account.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html#monitor-userdesc
+func (s *MonitorService) UserDesc(ctx context.Context) (MonitorUserDesc, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+
+	returnedDesc := monitorUserDescWrapper{}
+
+	res, err := s.client.post(ctx, methodMonitorUserDesc, payload, &returnedDesc)
+	if err != nil {
+		return MonitorUserDesc{}, res, err
+	}
+
+	return returnedDesc.Desc, res, nil
+}
+
+// Downs fetches the incidents detected by the monitors, most recent first.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/monitor.html#monitor-getdowns
+func (s *MonitorService) Downs(ctx context.Context, opts MonitorDownOptions) ([]MonitorDown, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+
+	if opts.Offset > 0 {
+		payload.Set("offset", strconv.Itoa(opts.Offset))
+	}
+
+	if opts.Length > 0 {
+		payload.Set("length", strconv.Itoa(opts.Length))
+	}
+
+	returnedDowns := monitorDownsWrapper{}
+
+	res, err := s.client.post(ctx, methodMonitorGetDowns, payload, &returnedDowns)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedDowns.Downs, res, nil
+}
@@ -0,0 +1,219 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMonitorService_ListSubValues(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Monitor.Listsubvalue", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain") != "example.com" || r.FormValue("subdomain") != "www" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"points": {
+				"sub_domain": "www",
+				"list": [{"record_id": "16894439", "value": "1.1.1.1", "record_line": "默认"}]
+			}}`)
+	})
+
+	points, _, err := client.Monitor.ListSubValues(context.Background(), "example.com", "www")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []MonitorPoint{{RecordID: "16894439", Value: "1.1.1.1", RecordLine: "默认"}}
+	if !reflect.DeepEqual(points, want) {
+		t.Errorf("got %+v, want %+v", points, want)
+	}
+}
+
+func TestMonitorService_Create(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Monitor.Create", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain_id") != "2059079" || r.FormValue("record_id") != "16894439" ||
+			r.FormValue("monitor_type") != "https" || r.FormValue("host") != "www.example.com" ||
+			r.FormValue("monitor_path") != "/health" || r.FormValue("port") != "443" ||
+			r.FormValue("monitor_interval") != "60" || r.FormValue("bak_ip") != "2.2.2.2,3.3.3.3" ||
+			r.FormValue("status_code") != "200,301" || r.FormValue("less_notice") != "no" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"monitor":{"monitor_id":"a9b6c5d4"}}`)
+	})
+
+	config := MonitorConfig{
+		Type:          MonitorTypeHTTPS,
+		Host:          "www.example.com",
+		Path:          "/health",
+		Port:          443,
+		ExpectedCodes: []int{200, 301},
+		Interval:      60,
+		Failover:      MonitorFailoverPause,
+		BackupIPs:     []string{"2.2.2.2", "3.3.3.3"},
+	}
+
+	monitorID, _, err := client.Monitor.Create(context.Background(), "2059079", "16894439", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if monitorID != "a9b6c5d4" {
+		t.Errorf("got %q, want %q", monitorID, "a9b6c5d4")
+	}
+}
+
+func TestMonitorService_Update_failover(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Monitor.Modify", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("monitor_id") != "a9b6c5d4" || r.FormValue("bak_ip") != "pass_paused" ||
+			r.FormValue("monitor_type") != "http" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
+	})
+
+	config := MonitorConfig{Type: MonitorTypeHTTP, Host: "www.example.com", Path: "/", Failover: MonitorFailoverPause}
+
+	_, err := client.Monitor.Update(context.Background(), "a9b6c5d4", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMonitorService_Get(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Monitor.Info", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"info": {
+				"monitor_id": "a9b6c5d4",
+				"domain_id": "2059079",
+				"domain": "example.com",
+				"record_id": "16894439",
+				"sub_domain": "www",
+				"ip": "1.1.1.1",
+				"port": 443,
+				"monitor_type": "https",
+				"monitor_path": "/health",
+				"monitor_interval": 60,
+				"bak_ip": "pass_paused",
+				"status": "enabled",
+				"now": "ok"
+			}}`)
+	})
+
+	monitor, _, err := client.Monitor.Get(context.Background(), "a9b6c5d4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Monitor{
+		MonitorID:       "a9b6c5d4",
+		DomainID:        "2059079",
+		Domain:          "example.com",
+		RecordID:        "16894439",
+		SubDomain:       "www",
+		IP:              "1.1.1.1",
+		Port:            "443",
+		MonitorType:     MonitorTypeHTTPS,
+		MonitorPath:     "/health",
+		MonitorInterval: "60",
+		BakIP:           "pass_paused",
+		Status:          MonitorStatusEnabled,
+		Now:             "ok",
+	}
+	if !reflect.DeepEqual(monitor, want) {
+		t.Errorf("got %+v, want %+v", monitor, want)
+	}
+}
+
+func TestMonitorService_History(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Monitor.Gethistory", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("monitor_id") != "a9b6c5d4" || r.FormValue("hours") != "3" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"monitor_history": [
+				{"created_at": "2026-10-17 10:00:00", "ip": "1.1.1.1", "status": "ok", "response_time": 35},
+				{"created_at": "2026-10-17 10:01:00", "ip": "1.1.1.1", "status": "warn", "status_code": 502}
+			]}`)
+	})
+
+	history, _, err := client.Monitor.History(context.Background(), "a9b6c5d4", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []MonitorHistory{
+		{CreatedAt: "2026-10-17 10:00:00", IP: "1.1.1.1", Status: "ok", ResponseTime: "35"},
+		{CreatedAt: "2026-10-17 10:01:00", IP: "1.1.1.1", Status: "warn", StatusCode: "502"},
+	}
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("got %+v, want %+v", history, want)
+	}
+}
+
+func TestMonitorService_Downs(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Monitor.Getdowns", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("offset") != "20" || r.FormValue("length") != "10" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"monitor_downs": [{
+				"monitor_id": "a9b6c5d4",
+				"domain": "example.com",
+				"sub_domain": "www",
+				"ip": "1.1.1.1",
+				"created_at": "2026-10-17 10:01:00",
+				"warn_reason": "502"
+			}]}`)
+	})
+
+	downs, _, err := client.Monitor.Downs(context.Background(), MonitorDownOptions{Offset: 20, Length: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []MonitorDown{{
+		MonitorID:  "a9b6c5d4",
+		Domain:     "example.com",
+		SubDomain:  "www",
+		IP:         "1.1.1.1",
+		CreatedAt:  "2026-10-17 10:01:00",
+		WarnReason: "502",
+	}}
+	if !reflect.DeepEqual(downs, want) {
+		t.Errorf("got %+v, want %+v", downs, want)
+	}
+}
@@ -0,0 +1,104 @@
+package dnspod
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// Logger is the interface used by the Client to report retries and throttled calls.
+// It is satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithHTTPClient sets the HTTP client used to communicate with the API.
+// The Timeout and KeepAlive of the CommonParams are ignored.
+// A nil client is ignored.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient == nil {
+			return
+		}
+
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithTransport sets the transport of the HTTP client used to communicate with the API.
+// The HTTP client given to WithHTTPClient, if any, is copied instead of being modified.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		httpClient := &http.Client{}
+		if c.HTTPClient != nil {
+			*httpClient = *c.HTTPClient
+		}
+
+		httpClient.Transport = transport
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithBaseURL sets the base URL for API requests (e.g. a sandbox or a proxy).
+// A trailing slash is added if missing.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+
+		c.BaseURL = baseURL
+	}
+}
+
+// WithUserAgent sets the user agent sent to the API.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithRetry sets the policy used to retry failed calls.
+// See DefaultRetryPolicy for a sensible default.
+func WithRetry(policy *RetryPolicy) Option {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
+
+// WithRateLimit sets the client-side rate limit, replacing the RateLimit of the CommonParams.
+func WithRateLimit(limit RateLimit) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(limit)
+	}
+}
+
+// WithLogger sets the logger used to report retries and throttled calls.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithTencentCloud switches the client to the Tencent Cloud API 3.0 (dnspod.tencentcloudapi.com),
+// authenticated with a SecretId and a SecretKey instead of the login token of the CommonParams.
+//
+// Only the listing, creation, fetching and deletion of domains, and the management of records,
+// are available through this API: the other methods return ErrUnsupported.
+// A WithBaseURL option, given before or after WithTencentCloud, overrides the endpoint.
+// The limits of a RateLimit, given by legacy method names (e.g. "Record.Modify"), apply to the equivalent actions.
+func WithTencentCloud(secretID, secretKey string) Option {
+	return func(c *Client) {
+		c.backend = &tencentCloud{client: c, secretID: secretID, secretKey: secretKey, service: tencentCloudService, now: time.Now}
+	}
+}
+
+// WithBackend replaces the API with an in-memory backend, created by NewFakeBackend.
+func WithBackend(backend *FakeBackend) Option {
+	return func(c *Client) {
+		c.backend = backend
+	}
+}
@@ -0,0 +1,114 @@
+package dnspod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestNewClient_options(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	client := NewClient(CommonParams{LoginToken: "DNSPod login token"},
+		WithHTTPClient(httpClient),
+		WithBaseURL("https://dnspod.example.com"),
+		WithUserAgent("my-agent"),
+		WithRetry(DefaultRetryPolicy()),
+	)
+
+	if client.HTTPClient != httpClient {
+		t.Errorf("got %v, want %v", client.HTTPClient, httpClient)
+	}
+
+	if client.BaseURL != "https://dnspod.example.com/" {
+		t.Errorf("got %v, want %v", client.BaseURL, "https://dnspod.example.com/")
+	}
+
+	if client.UserAgent != "my-agent" {
+		t.Errorf("got %v, want %v", client.UserAgent, "my-agent")
+	}
+
+	if client.RetryPolicy == nil {
+		t.Error("retry policy should be set")
+	}
+}
+
+func TestNewClient_WithHTTPClient_nil(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("unexpected request")
+	})
+
+	client := NewClient(CommonParams{}, WithHTTPClient(nil), WithTransport(transport))
+
+	if client.HTTPClient == nil || client.HTTPClient.Transport == nil {
+		t.Errorf("got %+v, want an HTTP client with the transport", client.HTTPClient)
+	}
+}
+
+func TestNewClient_WithTransport(t *testing.T) {
+	httpClient := &http.Client{}
+	logger := &recordingLogger{}
+
+	calls := 0
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+
+		body := `{"status": {"code":"1","message":""}}`
+		if calls == 1 {
+			body = `{"status": {"code":"-2","message":"API usage limit exceeded"}}`
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+
+	client := NewClient(CommonParams{LoginToken: "DNSPod login token"},
+		WithHTTPClient(httpClient),
+		WithTransport(transport),
+		WithRetry(policy),
+		WithLogger(logger),
+	)
+
+	if httpClient.Transport != nil {
+		t.Error("the given HTTP client should not be modified")
+	}
+
+	_, err := client.Records.DeleteWithContext(context.Background(), "44146112", "26954449")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "retrying") {
+		t.Errorf("unexpected logs: %v", logger.lines)
+	}
+}
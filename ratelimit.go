@@ -0,0 +1,199 @@
+package dnspod
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// maxKeyedBuckets is the number of per-resource buckets above which idle buckets are dropped.
+const maxKeyedBuckets = 1024
+
+// RateLimit configures the client-side rate limiter.
+// Calls exceeding the limit wait for their turn instead of being sent,
+// so that goroutines sharing a Client don't trip the server-side ban of the API token.
+//
+// For example, to allow 10 calls per second, and one modification per record every 5 seconds:
+//
+//	dnspod.RateLimit{
+//		Rate:  10,
+//		Burst: 10,
+//		Methods: map[string]dnspod.MethodRateLimit{
+//			"Record.Modify": {Rate: 0.2, KeyParam: "record_id"},
+//		},
+//	}
+type RateLimit struct {
+	// Rate is the number of calls per second allowed for all the methods.
+	// Zero means no global limit.
+	Rate float64
+
+	// Burst is the number of calls that can be sent at once. Defaults to 1.
+	Burst int
+
+	// Methods defines limits for specific API methods, applied in addition to the global limit.
+	Methods map[string]MethodRateLimit
+}
+
+// MethodRateLimit is the rate limit of an API method.
+type MethodRateLimit struct {
+	// Rate is the number of calls per second allowed for the method.
+	Rate float64
+
+	// Burst is the number of calls that can be sent at once. Defaults to 1.
+	Burst int
+
+	// KeyParam is the request parameter identifying the resource the limit applies to (e.g. "record_id").
+	// If not empty, each value of the parameter has its own limit.
+	KeyParam string
+}
+
+// rateLimiter is a set of token buckets: a global one, and one per method (or per resource).
+type rateLimiter struct {
+	global  *tokenBucket
+	methods map[string]MethodRateLimit
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	limiter := &rateLimiter{
+		methods: limit.Methods,
+		buckets: make(map[string]*tokenBucket),
+	}
+
+	if limit.Rate > 0 {
+		limiter.global = newTokenBucket(limit.Rate, limit.Burst)
+	}
+
+	return limiter
+}
+
+// wait blocks until the call of the method is allowed by all the applicable limits, or until ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, method string, payload url.Values) error {
+	now := time.Now()
+
+	var reserved []*tokenBucket
+	var delay time.Duration
+
+	for _, bucket := range []*tokenBucket{l.global, l.bucket(method, payload, now)} {
+		if bucket == nil {
+			continue
+		}
+
+		if d := bucket.reserve(now); d > delay {
+			delay = d
+		}
+
+		reserved = append(reserved, bucket)
+	}
+
+	if delay == 0 {
+		return nil
+	}
+
+	err := sleep(ctx, delay)
+	if err != nil {
+		for _, bucket := range reserved {
+			bucket.cancel()
+		}
+	}
+
+	return err
+}
+
+// bucket returns the bucket of the method, creating it if needed.
+func (l *rateLimiter) bucket(method string, payload url.Values, now time.Time) *tokenBucket {
+	limit, ok := l.methods[method]
+	if !ok || limit.Rate <= 0 {
+		return nil
+	}
+
+	key := method
+	if limit.KeyParam != "" {
+		key += "/" + payload.Get(limit.KeyParam)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if bucket, ok := l.buckets[key]; ok {
+		return bucket
+	}
+
+	if len(l.buckets) >= maxKeyedBuckets {
+		for k, bucket := range l.buckets {
+			if bucket.idle(now) {
+				delete(l.buckets, k)
+			}
+		}
+	}
+
+	bucket := newTokenBucket(limit.Rate, limit.Burst)
+	l.buckets[key] = bucket
+
+	return bucket
+}
+
+// tokenBucket is a token bucket refilled continuously at a fixed rate.
+type tokenBucket struct {
+	rate  float64 // tokens per second
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// reserve takes a token and returns the delay to wait before using it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back a token taken by reserve.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// idle reports whether the bucket is full, i.e. equivalent to a new bucket.
+func (b *tokenBucket) idle(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+
+	return b.tokens >= b.burst
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+
+	if now.After(b.last) {
+		b.last = now
+	}
+}
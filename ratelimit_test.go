@@ -0,0 +1,86 @@
+package dnspod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_reserve(t *testing.T) {
+	bucket := newTokenBucket(10, 2)
+	now := time.Now()
+
+	if d := bucket.reserve(now); d != 0 {
+		t.Errorf("got %v, want no delay", d)
+	}
+	if d := bucket.reserve(now); d != 0 {
+		t.Errorf("got %v, want no delay", d)
+	}
+	if d := bucket.reserve(now); d != 100*time.Millisecond {
+		t.Errorf("got %v, want %v", d, 100*time.Millisecond)
+	}
+
+	// the bucket is refilled with time.
+	if d := bucket.reserve(now.Add(time.Second)); d != 0 {
+		t.Errorf("got %v, want no delay", d)
+	}
+}
+
+func TestRateLimiter_keyParam(t *testing.T) {
+	limiter := newRateLimiter(RateLimit{
+		Methods: map[string]MethodRateLimit{
+			methodRecordModify: {Rate: 0.001, KeyParam: "record_id"},
+		},
+	})
+
+	ctx := context.Background()
+
+	// each record has its own bucket.
+	for _, id := range []string{"1", "2", "3"} {
+		err := limiter.wait(ctx, methodRecordModify, url.Values{"record_id": {id}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// other methods are not limited.
+	err := limiter.wait(ctx, methodRecordInfo, url.Values{"record_id": {"1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	err = limiter.wait(ctx, methodRecordModify, url.Values{"record_id": {"1"}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClient_rateLimit(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	client.limiter = newRateLimiter(RateLimit{Rate: 20, Burst: 1})
+
+	mux.HandleFunc("/Record.Remove", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.Records.DeleteWithContext(context.Background(), "44146112", fmt.Sprint(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 calls at 20 per second took %v, want at least 100ms", elapsed)
+	}
+}
@@ -0,0 +1,121 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const methodRecordType = "Record.Type"
+
+// RecordType is the type of a DNS record.
+type RecordType string
+
+// Record types.
+const (
+	RecordTypeA     RecordType = "A"
+	RecordTypeAAAA  RecordType = "AAAA"
+	RecordTypeCNAME RecordType = "CNAME"
+	RecordTypeMX    RecordType = "MX"
+	RecordTypeTXT   RecordType = "TXT"
+	RecordTypeNS    RecordType = "NS"
+	RecordTypeSRV   RecordType = "SRV"
+	RecordTypeCAA   RecordType = "CAA"
+	RecordTypeSPF   RecordType = "SPF"
+	RecordTypeHTTPS RecordType = "HTTPS"
+	RecordTypeSVCB  RecordType = "SVCB"
+
+	// URL forwarding, as named by the international API.
+	RecordTypeURL  RecordType = "URL"  // explicit forwarding (redirection)
+	RecordTypeURL1 RecordType = "URL1" // implicit forwarding (frame)
+
+	// URL forwarding, as named by the Chinese API.
+	RecordTypeExplicitURL RecordType = "显性URL"
+	RecordTypeImplicitURL RecordType = "隐性URL"
+)
+
+var knownRecordTypes = []RecordType{
+	RecordTypeA, RecordTypeAAAA, RecordTypeCNAME, RecordTypeMX, RecordTypeTXT, RecordTypeNS, RecordTypeSRV,
+	RecordTypeCAA, RecordTypeSPF, RecordTypeHTTPS, RecordTypeSVCB,
+	RecordTypeURL, RecordTypeURL1, RecordTypeExplicitURL, RecordTypeImplicitURL,
+}
+
+// Known reports whether the type is one of the record types defined by this package.
+func (t RecordType) Known() bool {
+	for _, known := range knownRecordTypes {
+		if t == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsURL reports whether the type is a URL forwarding.
+func (t RecordType) IsURL() bool {
+	switch t {
+	case RecordTypeURL, RecordTypeURL1, RecordTypeExplicitURL, RecordTypeImplicitURL:
+		return true
+	default:
+		return false
+	}
+}
+
+// recordTypeCache caches the record types allowed by each grade.
+type recordTypeCache struct {
+	mu      sync.Mutex
+	byGrade map[string][]RecordType
+}
+
+func (c *recordTypeCache) get(grade string) ([]RecordType, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	types, ok := c.byGrade[grade]
+
+	return types, ok
+}
+
+func (c *recordTypeCache) set(grade string, types []RecordType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.byGrade == nil {
+		c.byGrade = make(map[string][]RecordType)
+	}
+
+	c.byGrade[grade] = types
+}
+
+type recordTypeWrapper struct {
+	Types []RecordType `json:"types"`
+}
+
+// Types returns the record types allowed by a domain grade.
+// The types are fetched once per grade, and cached by the client.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/records.html#record-type
+func (s *RecordsService) Types(ctx context.Context, grade string) ([]RecordType, *Response, error) {
+	if !validGrade(grade) {
+		return nil, nil, fmt.Errorf("invalid grade of domain: %s", grade)
+	}
+
+	if types, ok := s.client.recordTypes.get(grade); ok {
+		return append([]RecordType(nil), types...), nil, nil
+	}
+
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Set("domain_grade", grade)
+
+	returnedTypes := recordTypeWrapper{}
+
+	res, err := s.client.post(ctx, methodRecordType, payload, &returnedTypes)
+	if err != nil {
+		return nil, res, err
+	}
+
+	s.client.recordTypes.set(grade, returnedTypes.Types)
+
+	return append([]RecordType(nil), returnedTypes.Types...), res, nil
+}
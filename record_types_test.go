@@ -0,0 +1,52 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRecordsService_Types(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/Record.Type", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if r.FormValue("domain_grade") != "DP_Free" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"types":["A","CNAME","MX","TXT","NS","AAAA","SRV","显性URL","隐性URL","CAA"]}`)
+	})
+
+	want := []RecordType{
+		RecordTypeA, RecordTypeCNAME, RecordTypeMX, RecordTypeTXT, RecordTypeNS, RecordTypeAAAA, RecordTypeSRV,
+		RecordTypeExplicitURL, RecordTypeImplicitURL, RecordTypeCAA,
+	}
+
+	for i := 0; i < 2; i++ {
+		types, _, err := client.Records.Types(context.Background(), "DP_Free")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(types, want) {
+			t.Errorf("got %+v, want %+v", types, want)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("got %d calls, want the types to be cached after 1 call", calls)
+	}
+
+	for _, recordType := range want {
+		if !recordType.Known() {
+			t.Errorf("%s should be a known type", recordType)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package dnspod
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"net/url"
 	"strconv"
 )
 
@@ -12,25 +14,30 @@ const (
 	methodRecordInfo   = "Record.Info"
 	methodRecordRemove = "Record.Remove"
 	methodRecordModify = "Record.Modify"
+	methodRecordRemark = "Record.Remark"
+	methodRecordStatus = "Record.Status"
+	methodRecordDdns   = "Record.Ddns"
 )
 
+const defaultRecordPageLength = 3000
+
 // Record is the DNS record representation.
 type Record struct {
-	ID            string `json:"id,omitempty"`
-	Name          string `json:"name,omitempty"`
-	Line          string `json:"line,omitempty"`
-	LineID        string `json:"line_id,omitempty"`
-	Type          string `json:"type,omitempty"`
-	TTL           string `json:"ttl,omitempty"`
-	Value         string `json:"value,omitempty"`
-	MX            string `json:"mx,omitempty"`
-	Enabled       string `json:"enabled,omitempty"`
-	Status        string `json:"status,omitempty"`
-	MonitorStatus string `json:"monitor_status,omitempty"`
-	Remark        string `json:"remark,omitempty"`
-	UpdateOn      string `json:"updated_on,omitempty"`
-	UseAQB        string `json:"use_aqb,omitempty"`
-	Weight        *int   `json:"weight,omitempty"`
+	ID            string     `json:"id,omitempty"`
+	Name          string     `json:"name,omitempty"`
+	Line          string     `json:"line,omitempty"`
+	LineID        string     `json:"line_id,omitempty"`
+	Type          RecordType `json:"type,omitempty"`
+	TTL           string     `json:"ttl,omitempty"`
+	Value         string     `json:"value,omitempty"`
+	MX            string     `json:"mx,omitempty"`
+	Enabled       string     `json:"enabled,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	MonitorStatus string     `json:"monitor_status,omitempty"`
+	Remark        string     `json:"remark,omitempty"`
+	UpdateOn      string     `json:"updated_on,omitempty"`
+	UseAQB        string     `json:"use_aqb,omitempty"`
+	Weight        *int       `json:"weight,omitempty"`
 }
 
 // RecordModify is the DNS record modify representation.
@@ -41,6 +48,27 @@ type RecordModify struct {
 	Status string      `json:"status,omitempty"`
 }
 
+// RecordStatus is the status of a record.
+type RecordStatus string
+
+// Record statuses.
+const (
+	RecordStatusEnable  RecordStatus = "enable"
+	RecordStatusDisable RecordStatus = "disable"
+)
+
+// RecordDDNS is the dynamic DNS update of a record.
+type RecordDDNS struct {
+	// Name is the sub domain of the record, e.g. "www".
+	Name   string
+	Line   string
+	LineID string
+
+	// Value is the new IP address of the record.
+	// The API uses the IP address of the caller if empty.
+	Value string
+}
+
 type DomainWithRecords struct {
 	Status  Status     `json:"status"`
 	Domain  Domain     `json:"domain"`
@@ -49,13 +77,11 @@ type DomainWithRecords struct {
 }
 
 type recordWrapper struct {
-	Status Status     `json:"status"`
 	Info   DomainInfo `json:"info"`
 	Record Record     `json:"record"`
 }
 
 type recordModifyWrapper struct {
-	Status Status       `json:"status"`
 	Record RecordModify `json:"record"`
 }
 
@@ -68,195 +94,340 @@ type RecordsService struct {
 	client *Client
 }
 
-// List List the domain records.
-//
-// DNSPod API docs:
-// - https://www.dnspod.cn/docs/records.html#record-list
-// - https://docs.dnspod.com/api/5fe19a7a6e336701a2111bb9/
-func (s *RecordsService) List(domainID, recordName string) (*DomainWithRecords, *Response, error) {
-	payload := s.client.CommonParams.toPayLoad()
-	payload.Add("domain_id", domainID)
-	if recordName != "" {
-		payload.Add("sub_domain", recordName)
+// RecordListOptions are the filters and pagination of the record list.
+type RecordListOptions struct {
+	SubDomain    string
+	RecordType   RecordType
+	RecordLine   string
+	RecordLineID string
+	RecordID     string
+	Keyword      string
+
+	// Offset of the first record.
+	Offset int
+
+	// Length is the number of records per page.
+	// Defaults to 3000.
+	Length int
+}
+
+func (o RecordListOptions) setPayload(payload url.Values) {
+	if o.SubDomain != "" {
+		payload.Set("sub_domain", o.SubDomain)
 	}
 
-	wrappedRecords := &DomainWithRecords{}
+	if o.RecordType != "" {
+		payload.Set("record_type", string(o.RecordType))
+	}
 
-	res, err := s.client.post(methodRecordList, payload, wrappedRecords)
-	if err != nil {
-		return nil, res, err
+	if o.RecordLine != "" {
+		payload.Set("record_line", o.RecordLine)
 	}
 
-	if wrappedRecords.Status.Code != "1" {
-		return nil, nil, fmt.Errorf("code: %s, message: %s", wrappedRecords.Status.Code, wrappedRecords.Status.Message)
+	if o.RecordLineID != "" {
+		payload.Set("record_line_id", o.RecordLineID)
 	}
 
-	return wrappedRecords, res, nil
+	if o.RecordID != "" {
+		payload.Set("record_id", o.RecordID)
+	}
+
+	if o.Keyword != "" {
+		payload.Set("keyword", o.Keyword)
+	}
+
+	payload.Set("offset", strconv.Itoa(o.Offset))
+	payload.Set("length", strconv.Itoa(o.Length))
 }
 
-// Create Creates a domain record.
-//
-// DNSPod API docs:
-// - https://www.dnspod.cn/docs/records.html#record-create
-// - https://docs.dnspod.com/api/5fe19a3f6e336701a2111bb0/
-func (s *RecordsService) Create(domain string, recordAttributes Record) (Record, *Response, error) {
-	payload := s.client.CommonParams.toPayLoad()
-	payload.Add("domain_id", domain)
+// RecordPager fetches the pages of the record list lazily, one call per page.
+type RecordPager struct {
+	service  *RecordsService
+	domainID string
+	opts     RecordListOptions
+	done     bool
+}
 
-	if recordAttributes.Name != "" {
-		payload.Add("sub_domain", recordAttributes.Name)
-	}
+// HasNext reports whether there are pages left to fetch.
+func (p *RecordPager) HasNext() bool {
+	return !p.done
+}
 
-	if recordAttributes.Type != "" {
-		payload.Add("record_type", recordAttributes.Type)
+// Next fetches the next page.
+// A page without records is returned once all the records have been fetched.
+func (p *RecordPager) Next(ctx context.Context) (*DomainWithRecords, *Response, error) {
+	if p.done {
+		return &DomainWithRecords{}, nil, nil
 	}
 
-	if recordAttributes.Line != "" {
-		payload.Add("record_line", recordAttributes.Line)
+	wrappedRecords, res, err := p.service.client.backend.ListRecords(ctx, p.domainID, p.opts)
+	if errors.Is(err, ErrEmptyResult) {
+		p.done = true
+		return &DomainWithRecords{}, res, nil
 	}
-
-	if recordAttributes.LineID != "" {
-		payload.Add("record_line_id", recordAttributes.LineID)
+	if err != nil {
+		return nil, res, err
 	}
 
-	if recordAttributes.Value != "" {
-		payload.Add("value", recordAttributes.Value)
+	p.opts.Offset += len(wrappedRecords.Records)
+
+	// the total is authoritative when known, as the API may cap the length of the pages.
+	total, errTotal := wrappedRecords.Info.RecordsNum.Int64()
+	switch {
+	case len(wrappedRecords.Records) == 0:
+		p.done = true
+	case errTotal == nil:
+		p.done = int64(p.opts.Offset) >= total
+	default:
+		p.done = len(wrappedRecords.Records) < p.opts.Length
 	}
 
-	if recordAttributes.MX != "" {
-		payload.Add("mx", recordAttributes.MX)
-	}
+	return wrappedRecords, res, nil
+}
 
-	if recordAttributes.TTL != "" {
-		payload.Add("ttl", recordAttributes.TTL)
-	}
+// RecordIterator iterates over the records of a domain, fetching the pages lazily.
+type RecordIterator struct {
+	pager   *RecordPager
+	records []Record
+	current Record
+	err     error
+}
 
-	if recordAttributes.Status != "" {
-		payload.Add("status", recordAttributes.Status)
+// Next advances the iterator to the next record, fetching the next page if needed.
+// It returns false when there are no records left, or when an error occurred.
+func (it *RecordIterator) Next(ctx context.Context) bool {
+	for len(it.records) == 0 {
+		if it.err != nil || !it.pager.HasNext() {
+			return false
+		}
+
+		var page *DomainWithRecords
+		page, _, it.err = it.pager.Next(ctx)
+		if page != nil {
+			it.records = page.Records
+		}
 	}
 
-	if recordAttributes.Weight != nil {
-		payload.Add("weight", strconv.Itoa(*recordAttributes.Weight))
-	}
+	it.current, it.records = it.records[0], it.records[1:]
+
+	return true
+}
 
-	returnedRecord := recordWrapper{}
+// Record returns the current record.
+func (it *RecordIterator) Record() Record {
+	return it.current
+}
 
-	res, err := s.client.post(methodRecordCreate, payload, &returnedRecord)
-	if err != nil {
-		return Record{}, res, err
+// Err returns the error which stopped the iteration, if any.
+func (it *RecordIterator) Err() error {
+	return it.err
+}
+
+// ListPages returns a pager over the records of the domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/records.html#record-list
+// - https://docs.dnspod.com/api/5fe19a7a6e336701a2111bb9/
+func (s *RecordsService) ListPages(domainID string, opts RecordListOptions) *RecordPager {
+	if opts.Length <= 0 {
+		opts.Length = defaultRecordPageLength
 	}
 
-	if returnedRecord.Status.Code != "1" {
-		return returnedRecord.Record, nil, fmt.Errorf("code: %s, message: %s", returnedRecord.Status.Code, returnedRecord.Status.Message)
+	return &RecordPager{service: s, domainID: domainID, opts: opts}
+}
+
+// Iter returns an iterator over the records of the domain.
+func (s *RecordsService) Iter(domainID string, opts RecordListOptions) *RecordIterator {
+	return &RecordIterator{pager: s.ListPages(domainID, opts)}
+}
+
+// ListAll fetches all the records of the domain matching the options, page by page.
+// The domain and the information are the ones of the first page.
+func (s *RecordsService) ListAll(ctx context.Context, domainID string, opts RecordListOptions) (*DomainWithRecords, *Response, error) {
+	pager := s.ListPages(domainID, opts)
+
+	var all *DomainWithRecords
+	var res *Response
+
+	for pager.HasNext() {
+		var page *DomainWithRecords
+		var err error
+
+		page, res, err = pager.Next(ctx)
+		if err != nil {
+			return nil, res, err
+		}
+
+		if all == nil {
+			all = page
+		} else {
+			all.Records = append(all.Records, page.Records...)
+		}
 	}
 
-	return returnedRecord.Record, res, nil
+	return all, res, nil
 }
 
-// Get Fetches the domain record.
+// List List the domain records, optionally filtered by sub domain.
+//
+// Deprecated: use ListWithContext. List will be removed in the next release.
+func (s *RecordsService) List(domainID, recordName string) (*DomainWithRecords, *Response, error) {
+	return s.ListWithContext(context.Background(), domainID, recordName)
+}
+
+// ListWithContext List the domain records, optionally filtered by sub domain.
+// All the pages are fetched.
 //
 // DNSPod API docs:
-// - https://www.dnspod.cn/docs/records.html#record-info
-// - https://docs.dnspod.com/api/5fe1a2a06e336701a2111bcd/
-func (s *RecordsService) Get(domain, recordID string) (Record, *Response, error) {
-	payload := s.client.CommonParams.toPayLoad()
-	payload.Add("domain_id", domain)
-	payload.Add("record_id", recordID)
+// - https://www.dnspod.cn/docs/records.html#record-list
+// - https://docs.dnspod.com/api/5fe19a7a6e336701a2111bb9/
+func (s *RecordsService) ListWithContext(ctx context.Context, domainID, recordName string) (*DomainWithRecords, *Response, error) {
+	return s.ListAll(ctx, domainID, RecordListOptions{SubDomain: recordName})
+}
+
+// Create Creates a domain record.
+//
+// Deprecated: use CreateWithContext. Create will be removed in the next release.
+func (s *RecordsService) Create(domain string, recordAttributes Record) (Record, *Response, error) {
+	return s.CreateWithContext(context.Background(), domain, recordAttributes)
+}
 
-	returnedRecord := recordWrapper{}
+// CreateWithContext Creates a domain record.
+// If only the line name, or the line id, of the record is set, the other one is completed from the lines cached by the LineResolver of the client.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/records.html#record-create
+// - https://docs.dnspod.com/api/5fe19a3f6e336701a2111bb0/
+func (s *RecordsService) CreateWithContext(ctx context.Context, domain string, recordAttributes Record) (Record, *Response, error) {
+	s.client.LineResolver.complete(domain, &recordAttributes)
 
-	res, err := s.client.post(methodRecordInfo, payload, &returnedRecord)
-	if err != nil {
-		return Record{}, res, err
-	}
+	return s.client.backend.CreateRecord(ctx, domain, recordAttributes)
+}
 
-	if returnedRecord.Status.Code != "1" {
-		return returnedRecord.Record, nil, fmt.Errorf("code: %s, message: %s", returnedRecord.Status.Code, returnedRecord.Status.Message)
-	}
+// Get Fetches the domain record.
+//
+// Deprecated: use GetWithContext. Get will be removed in the next release.
+func (s *RecordsService) Get(domain, recordID string) (Record, *Response, error) {
+	return s.GetWithContext(context.Background(), domain, recordID)
+}
 
-	return returnedRecord.Record, res, nil
+// GetWithContext Fetches the domain record.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/records.html#record-info
+// - https://docs.dnspod.com/api/5fe1a2a06e336701a2111bcd/
+func (s *RecordsService) GetWithContext(ctx context.Context, domain, recordID string) (Record, *Response, error) {
+	return s.client.backend.GetRecord(ctx, domain, recordID)
 }
 
 // Update Updates a domain record.
 //
+// Deprecated: use UpdateWithContext. Update will be removed in the next release.
+func (s *RecordsService) Update(domain, recordID string, recordAttributes Record) (RecordModify, *Response, error) {
+	return s.UpdateWithContext(context.Background(), domain, recordID, recordAttributes)
+}
+
+// UpdateWithContext Updates a domain record.
+// If only the line name, or the line id, of the record is set, the other one is completed from the lines cached by the LineResolver of the client.
+//
 // DNSPod API docs:
 // - https://www.dnspod.cn/docs/records.html#record-modify
 // - https://docs.dnspod.com/api/5fe1a5a16e336701a2111c76/
-func (s *RecordsService) Update(domain, recordID string, recordAttributes Record) (RecordModify, *Response, error) {
-	payload := s.client.CommonParams.toPayLoad()
-	payload.Add("domain_id", domain)
-	payload.Add("record_id", recordID)
-
-	if recordAttributes.Name != "" {
-		payload.Add("sub_domain", recordAttributes.Name)
-	}
-
-	if recordAttributes.Type != "" {
-		payload.Add("record_type", recordAttributes.Type)
-	}
-
-	if recordAttributes.Line != "" {
-		payload.Add("record_line", recordAttributes.Line)
-	}
+func (s *RecordsService) UpdateWithContext(ctx context.Context, domain, recordID string, recordAttributes Record) (RecordModify, *Response, error) {
+	s.client.LineResolver.complete(domain, &recordAttributes)
 
-	if recordAttributes.LineID != "" {
-		payload.Add("record_line_id", recordAttributes.LineID)
-	}
+	return s.client.backend.UpdateRecord(ctx, domain, recordID, recordAttributes)
+}
 
-	if recordAttributes.Value != "" {
-		payload.Add("value", recordAttributes.Value)
-	}
+// Delete Deletes a domain record.
+//
+// Deprecated: use DeleteWithContext. Delete will be removed in the next release.
+func (s *RecordsService) Delete(domainId, recordId string) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), domainId, recordId)
+}
 
-	if recordAttributes.MX != "" {
-		payload.Add("mx", recordAttributes.MX)
-	}
+// DeleteWithContext Deletes a domain record.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/records.html#record-remove
+// - https://docs.dnspod.com/api/5fe1a4576e336701a2111c24/
+func (s *RecordsService) DeleteWithContext(ctx context.Context, domainId, recordId string) (*Response, error) {
+	return s.client.backend.DeleteRecord(ctx, domainId, recordId)
+}
 
-	if recordAttributes.TTL != "" {
-		payload.Add("ttl", recordAttributes.TTL)
-	}
+// SetRemark sets the remark of a record. An empty remark removes it.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/records.html#record-remark
+func (s *RecordsService) SetRemark(ctx context.Context, domainID, recordID, remark string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Add("domain_id", domainID)
+	payload.Add("record_id", recordID)
+	payload.Add("remark", remark)
 
-	if recordAttributes.Status != "" {
-		payload.Add("status", recordAttributes.Status)
-	}
+	return s.client.post(ctx, methodRecordRemark, payload, nil)
+}
 
-	if recordAttributes.Weight != nil {
-		payload.Add("weight", strconv.Itoa(*recordAttributes.Weight))
-	}
+// SetStatus enables or disables a record, without modifying it.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/records.html#record-status
+func (s *RecordsService) SetStatus(ctx context.Context, domainID, recordID string, status RecordStatus) (RecordModify, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	payload.Add("domain_id", domainID)
+	payload.Add("record_id", recordID)
+	payload.Add("status", string(status))
 
 	returnedRecord := recordModifyWrapper{}
 
-	res, err := s.client.post(methodRecordModify, payload, &returnedRecord)
+	res, err := s.client.post(ctx, methodRecordStatus, payload, &returnedRecord)
 	if err != nil {
 		return RecordModify{}, res, err
 	}
 
-	if returnedRecord.Status.Code != "1" {
-		return returnedRecord.Record, nil, fmt.Errorf("code: %s, message: %s", returnedRecord.Status.Code, returnedRecord.Status.Message)
-	}
-
 	return returnedRecord.Record, res, nil
 }
 
-// Delete Deletes a domain record.
+// DDNS updates the IP address of a record, for dynamic DNS.
+// Unlike Update, the type of the record doesn't have to be sent again.
 //
 // DNSPod API docs:
-// - https://www.dnspod.cn/docs/records.html#record-remove
-// - https://docs.dnspod.com/api/5fe1a4576e336701a2111c24/
-func (s *RecordsService) Delete(domainId, recordId string) (*Response, error) {
+// - https://www.dnspod.cn/docs/records.html#dns
+func (s *RecordsService) DDNS(ctx context.Context, domainID, recordID string, ddns RecordDDNS) (RecordModify, *Response, error) {
+	if ddns.Line == "" && ddns.LineID == "" {
+		return RecordModify{}, nil, errors.New("the line or the line ID of the record is required")
+	}
+
+	line := Record{Line: ddns.Line, LineID: ddns.LineID}
+	s.client.LineResolver.complete(domainID, &line)
+	ddns.Line, ddns.LineID = line.Line, line.LineID
+
 	payload := s.client.CommonParams.toPayLoad()
-	payload.Add("domain_id", domainId)
-	payload.Add("record_id", recordId)
+	payload.Add("domain_id", domainID)
+	payload.Add("record_id", recordID)
+
+	if ddns.Name != "" {
+		payload.Add("sub_domain", ddns.Name)
+	}
 
-	returnedRecord := recordWrapper{}
+	if ddns.Line != "" {
+		payload.Add("record_line", ddns.Line)
+	}
 
-	res, err := s.client.post(methodRecordRemove, payload, &returnedRecord)
-	if err != nil {
-		return res, err
+	if ddns.LineID != "" {
+		payload.Add("record_line_id", ddns.LineID)
+	}
+
+	if ddns.Value != "" {
+		payload.Add("value", ddns.Value)
 	}
 
-	if returnedRecord.Status.Code != "1" {
-		return nil, fmt.Errorf("code: %s, message: %s", returnedRecord.Status.Code, returnedRecord.Status.Message)
+	returnedRecord := recordModifyWrapper{}
+
+	res, err := s.client.post(ctx, methodRecordDdns, payload, &returnedRecord)
+	if err != nil {
+		return RecordModify{}, res, err
 	}
 
-	return res, nil
+	return returnedRecord.Record, res, nil
 }
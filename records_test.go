@@ -1,6 +1,7 @@
 package dnspod
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -26,14 +27,14 @@ func TestRecordsService_ListRecords_all(t *testing.T) {
 			]}`)
 	})
 
-	records, _, err := client.Records.List("example.com", "")
+	records, _, err := client.Records.ListWithContext(context.Background(), "example.com", "")
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	want := []Record{{ID: "44146112", Name: "yizerowwwww"}, {ID: "44146112", Name: "yizerowwwww"}}
-	if !reflect.DeepEqual(records, want) {
-		t.Errorf("got %+v, want %+v", records, want)
+	if !reflect.DeepEqual(records.Records, want) {
+		t.Errorf("got %+v, want %+v", records.Records, want)
 	}
 }
 
@@ -61,8 +62,76 @@ func TestRecordsService_ListRecords_subdomain(t *testing.T) {
 	}
 
 	want := []Record{{ID: "44146112", Name: "yizerowwwww"}, {ID: "44146112", Name: "yizerowwwww"}}
-	if !reflect.DeepEqual(records, want) {
-		t.Errorf("got returned %+v, want %+v", records, want)
+	if !reflect.DeepEqual(records.Records, want) {
+		t.Errorf("got returned %+v, want %+v", records.Records, want)
+	}
+}
+
+func TestRecordsService_ListAll(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Record.List", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("record_type") != "A" || r.FormValue("length") != "2" {
+			http.Error(w, "unexpected filters", http.StatusBadRequest)
+			return
+		}
+
+		switch r.FormValue("offset") {
+		case "0":
+			_, _ = fmt.Fprint(w, `{"status": {"code":"1"}, "info": {"records_num":"3"}, "records":[{"id":"1"},{"id":"2"}]}`)
+		case "2":
+			_, _ = fmt.Fprint(w, `{"status": {"code":"1"}, "info": {"records_num":"3"}, "records":[{"id":"3"}]}`)
+		default:
+			http.Error(w, "unexpected offset", http.StatusBadRequest)
+		}
+	})
+
+	records, _, err := client.Records.ListAll(context.Background(), "11223344", RecordListOptions{RecordType: "A", Length: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Record{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	if !reflect.DeepEqual(records.Records, want) {
+		t.Errorf("got %+v, want %+v", records.Records, want)
+	}
+
+	var ids []string
+	it := client.Records.Iter("11223344", RecordListOptions{RecordType: "A", Length: 2})
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Record().ID)
+	}
+
+	if it.Err() != nil || !reflect.DeepEqual(ids, []string{"1", "2", "3"}) {
+		t.Errorf("got %v (error: %v), want [1 2 3]", ids, it.Err())
+	}
+}
+
+func TestRecordsService_ListAll_cappedPages(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	// the server returns at most 2 records per page, whatever the length.
+	mux.HandleFunc("/Record.List", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("offset") {
+		case "0":
+			_, _ = fmt.Fprint(w, `{"status": {"code":"1"}, "info": {"records_num":"3"}, "records":[{"id":"1"},{"id":"2"}]}`)
+		case "2":
+			_, _ = fmt.Fprint(w, `{"status": {"code":"1"}, "info": {"records_num":"3"}, "records":[{"id":"3"}]}`)
+		default:
+			http.Error(w, "unexpected offset", http.StatusBadRequest)
+		}
+	})
+
+	records, _, err := client.Records.ListAll(context.Background(), "11223344", RecordListOptions{Length: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Record{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	if !reflect.DeepEqual(records.Records, want) {
+		t.Errorf("got %+v, want %+v", records.Records, want)
 	}
 }
 
@@ -154,7 +223,7 @@ func TestRecordsService_DeleteRecord(t *testing.T) {
 		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
 	})
 
-	_, err := client.Records.Delete("44146112", "26954449")
+	_, err := client.Records.DeleteWithContext(context.Background(), "44146112", "26954449")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -183,3 +252,62 @@ func TestRecordsService_DeleteRecord_failed(t *testing.T) {
 		t.Errorf("got %+v, should match %+v", err, match)
 	}
 }
+
+func TestRecordsService_SetStatus(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Record.Status", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("record_id") != "26954449" || r.FormValue("status") != "disable" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"record":{"id":26954449, "name":"@", "status":"disable"}}`)
+	})
+
+	record, _, err := client.Records.SetStatus(context.Background(), "44146112", "26954449", RecordStatusDisable)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := RecordModify{ID: "26954449", Name: "@", Status: "disable"}
+	if !reflect.DeepEqual(record, want) {
+		t.Errorf("got %+v, want %+v", record, want)
+	}
+}
+
+func TestRecordsService_DDNS(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Record.Ddns", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("sub_domain") != "home" || r.FormValue("value") != "1.2.3.4" || r.FormValue("record_type") != "" ||
+			r.FormValue("record_line") != "默认" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"record":{"id":26954449, "name":"home", "value":"1.2.3.4"}}`)
+	})
+
+	record, _, err := client.Records.DDNS(context.Background(), "44146112", "26954449", RecordDDNS{Name: "home", Line: "default", Value: "1.2.3.4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := RecordModify{ID: "26954449", Name: "home", Value: "1.2.3.4"}
+	if !reflect.DeepEqual(record, want) {
+		t.Errorf("got %+v, want %+v", record, want)
+	}
+}
+
+func TestRecordsService_DDNS_noLine(t *testing.T) {
+	client, _, teardown := setupClient()
+	defer teardown()
+
+	_, _, err := client.Records.DDNS(context.Background(), "44146112", "26954449", RecordDDNS{Name: "home", Value: "1.2.3.4"})
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,183 @@
+package dnspod
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed API calls are retried by the Client.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for a call, including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the base delay before the first retry, doubled for each following retry.
+	// A random jitter of up to half of the delay is removed from each wait.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+
+	// RetryableCodes are the DNSPod status codes considered transient.
+	RetryableCodes []string
+
+	// RetryableHTTPStatuses are the HTTP status codes considered transient.
+	RetryableHTTPStatuses []int
+
+	// NonIdempotentMethods are the API methods that must not be sent twice,
+	// unless the API explicitly rejected them because of a rate limit.
+	// Network errors and other transient failures are not retried for these methods,
+	// because the first attempt may have been applied.
+	NonIdempotentMethods []string
+
+	// OnRetry, if not nil, is called before waiting for each retry.
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a failed attempt which is about to be retried.
+type RetryEvent struct {
+	Method  string        // API method, e.g. "Record.List"
+	Attempt int           // number of the failed attempt, starting at 1
+	Delay   time.Duration // delay before the next attempt
+	Err     error         // error of the failed attempt
+}
+
+// DefaultRetryPolicy returns a RetryPolicy retrying rate-limited calls and gateway failures
+// up to 3 times, without ever duplicating a creation.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		RetryableCodes: []string{
+			"-2",                   // API usage limit exceeded
+			"RequestLimitExceeded", // Tencent Cloud API
+		},
+		RetryableHTTPStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		NonIdempotentMethods: []string{
+			methodDomainCreate,
+			methodDomainGroupAdd,
+			methodDomainAliasAdd,
+			methodDomainShare,
+			methodDomainAcquire,
+			methodDomainAcquireSend,
+			methodRecordCreate,
+			methodBatchDomainCreate,
+			methodBatchRecordCreate,
+			methodCustomLineCreate,
+			methodLineGroupCreate,
+			methodMonitorCreate,
+			methodSnapshotCreate,
+			methodTelephoneVerifyCode,
+			actionCreateDomain,
+			actionCreateRecord,
+		},
+	}
+}
+
+// retryable reports whether the failed attempt of the method should be retried.
+func (p *RetryPolicy) retryable(method string, attempt int, err error) bool {
+	if p == nil || err == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	transient := false
+
+	var apiErr *APIError
+	var errResp *ErrorResponse
+	var urlErr *url.Error
+
+	switch {
+	case errors.As(err, &apiErr):
+		transient = containsString(p.RetryableCodes, apiErr.Code)
+	case errors.As(err, &errResp):
+		transient = errResp.Response != nil && containsInt(p.RetryableHTTPStatuses, errResp.Response.StatusCode)
+	case errors.As(err, &urlErr):
+		// the request may have reached the server: only safe for idempotent methods.
+		transient = true
+	}
+
+	if !transient {
+		return false
+	}
+
+	if containsString(p.NonIdempotentMethods, method) {
+		return errors.Is(err, ErrRateLimited)
+	}
+
+	return true
+}
+
+// backoff returns the delay to wait after the failed attempt.
+// The Retry-After header of the response, if any, takes precedence over a shorter backoff.
+func (p *RetryPolicy) backoff(attempt int, res *Response) time.Duration {
+	delay := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	if half := int64(delay / 2); half > 0 {
+		delay -= time.Duration(rand.Int63n(half)) //nolint:gosec // jitter doesn't need a secure random source.
+	}
+
+	if res != nil && res.Response != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			if after := time.Duration(seconds) * time.Second; after > delay {
+				delay = after
+			}
+		}
+	}
+
+	return delay
+}
+
+// sleep waits for the delay, or until ctx is done.
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
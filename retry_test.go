@@ -0,0 +1,182 @@
+package dnspod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func setupRetryPolicy() (*RetryPolicy, *[]RetryEvent) {
+	var events []RetryEvent
+
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	policy.OnRetry = func(event RetryEvent) {
+		events = append(events, event)
+	}
+
+	return policy, &events
+}
+
+func TestClient_retry_rateLimited(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	policy, events := setupRetryPolicy()
+	client.RetryPolicy = policy
+
+	calls := 0
+	mux.HandleFunc("/Record.Create", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			_, _ = fmt.Fprint(w, `{"status": {"code":"-2","message":"API usage limit exceeded"}}`)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"record":{"id":"26954449", "name":"@"}}`)
+	})
+
+	record, _, err := client.Records.CreateWithContext(context.Background(), "44146112", Record{Name: "@"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if record.ID != "26954449" {
+		t.Errorf("got %+v, want record 26954449", record)
+	}
+
+	if len(*events) != 1 || (*events)[0].Method != methodRecordCreate || (*events)[0].Attempt != 1 {
+		t.Errorf("unexpected retry events: %+v", *events)
+	}
+}
+
+func TestClient_retry_exhausted(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	policy, events := setupRetryPolicy()
+	client.RetryPolicy = policy
+
+	mux.HandleFunc("/Record.List", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = fmt.Fprint(w, `{"message":"Bad gateway"}`)
+	})
+
+	_, _, err := client.Records.ListWithContext(context.Background(), "44146112", "")
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("got %v, want *ErrorResponse", err)
+	}
+
+	if len(*events) != policy.MaxAttempts-1 {
+		t.Errorf("got %d retries, want %d", len(*events), policy.MaxAttempts-1)
+	}
+}
+
+func TestClient_retry_gatewayHTML(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	policy, events := setupRetryPolicy()
+	client.RetryPolicy = policy
+
+	calls := 0
+	mux.HandleFunc("/Domain.Info", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprint(w, `<html><body><h1>503 Service Temporarily Unavailable</h1></body></html>`)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"domain": {"id":1, "name":"example.com"}}`)
+	})
+
+	domain, _, err := client.Domains.GetWithContext(context.Background(), "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if domain.Name != "example.com" {
+		t.Errorf("got %+v, want example.com", domain)
+	}
+
+	if len(*events) != 1 {
+		t.Fatalf("unexpected retry events: %+v", *events)
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As((*events)[0].Err, &errResp) || errResp.Message != "<html><body><h1>503 Service Temporarily Unavailable</h1></body></html>" {
+		t.Errorf("got %#v, want an *ErrorResponse with the HTML body as message", (*events)[0].Err)
+	}
+}
+
+func TestClient_retry_nonIdempotent(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	policy, events := setupRetryPolicy()
+	client.RetryPolicy = policy
+
+	calls := 0
+	mux.HandleFunc("/Record.Create", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = fmt.Fprint(w, `{"message":"Bad gateway"}`)
+	})
+
+	_, _, err := client.Records.CreateWithContext(context.Background(), "44146112", Record{Name: "@"})
+	if err == nil {
+		t.Fatal("an error was expected")
+	}
+
+	if calls != 1 || len(*events) != 0 {
+		t.Errorf("got %d calls and %d retries, want 1 call and no retry", calls, len(*events))
+	}
+}
+
+func TestDefaultRetryPolicy_nonIdempotentMethods(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	for _, method := range []string{
+		methodDomainCreate,
+		methodDomainGroupAdd,
+		methodDomainAliasAdd,
+		methodDomainShare,
+		methodRecordCreate,
+		methodCustomLineCreate,
+		methodLineGroupCreate,
+		methodMonitorCreate,
+		methodSnapshotCreate,
+	} {
+		if !containsString(policy.NonIdempotentMethods, method) {
+			t.Errorf("%s: want a non-idempotent method", method)
+		}
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	testCases := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{attempt: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{attempt: 10, min: 500 * time.Millisecond, max: time.Second},
+	}
+
+	for _, test := range testCases {
+		delay := policy.backoff(test.attempt, nil)
+		if delay < test.min || delay > test.max {
+			t.Errorf("attempt %d: got %v, want between %v and %v", test.attempt, delay, test.min, test.max)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package dnspod
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+const (
+	methodSnapshotList     = "Snapshot.List"
+	methodSnapshotCreate   = "Snapshot.Create"
+	methodSnapshotRemove   = "Snapshot.Remove"
+	methodSnapshotRollback = "Snapshot.Rollback"
+	methodSnapshotConfig   = "Snapshot.Config"
+	methodSnapshotDownload = "Snapshot.Download"
+)
+
+// Snapshot is a backup of the records of a domain.
+type Snapshot struct {
+	ID          string      `json:"id,omitempty"`
+	Domain      string      `json:"domain,omitempty"`
+	CreatedOn   string      `json:"created_on,omitempty"`
+	RecordCount json.Number `json:"record_count,omitempty"`
+	Hash        string      `json:"hash,omitempty"`
+}
+
+// SnapshotPeriod is the period of the automatic snapshots of a domain.
+type SnapshotPeriod string
+
+// Snapshot periods.
+const (
+	SnapshotPeriodNone    SnapshotPeriod = "none"
+	SnapshotPeriodHourly  SnapshotPeriod = "hourly"
+	SnapshotPeriodDaily   SnapshotPeriod = "daily"
+	SnapshotPeriodWeekly  SnapshotPeriod = "weekly"
+	SnapshotPeriodMonthly SnapshotPeriod = "monthly"
+)
+
+// SnapshotRecord is a record saved in a snapshot.
+type SnapshotRecord struct {
+	Name  string     `json:"sub_domain"`
+	Type  RecordType `json:"record_type"`
+	Line  string     `json:"record_line,omitempty"`
+	Value string     `json:"value"`
+	TTL   string     `json:"ttl,omitempty"`
+	MX    string     `json:"mx,omitempty"`
+}
+
+func (r SnapshotRecord) key() string {
+	mx := ""
+	if r.Type == RecordTypeMX {
+		mx = r.MX
+	}
+
+	return strings.Join([]string{r.Name, string(r.Type), r.Line, r.Value, mx, r.TTL}, "\x00")
+}
+
+// SnapshotDiff is the difference between a snapshot and the current records of a domain.
+// A modified record is reported both as removed (its old version) and as added (its new version).
+type SnapshotDiff struct {
+	// Added are the current records missing from the snapshot.
+	Added []SnapshotRecord
+
+	// Removed are the records of the snapshot missing from the current records,
+	// i.e. the records restored by a rollback.
+	Removed []SnapshotRecord
+}
+
+// Empty reports whether the snapshot matches the current records.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+type snapshotsWrapper struct {
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+type snapshotWrapper struct {
+	Snapshot Snapshot `json:"snapshot"`
+}
+
+type snapshotDownloadWrapper struct {
+	Records []SnapshotRecord `json:"records"`
+}
+
+// SnapshotService handles communication with the snapshot related methods of the DNSPod API.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/snapshot.html
+type SnapshotService struct {
+	client *Client
+}
+
+// List lists the snapshots of a domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/snapshot.html#snapshot-list
+func (s *SnapshotService) List(ctx context.Context, domainID string) ([]Snapshot, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+
+	returnedSnapshots := snapshotsWrapper{}
+
+	res, err := s.client.post(ctx, methodSnapshotList, payload, &returnedSnapshots)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedSnapshots.Snapshots, res, nil
+}
+
+// Create creates a snapshot of the records of a domain, and returns it.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/snapshot.html#snapshot-create
+func (s *SnapshotService) Create(ctx context.Context, domainID string) (Snapshot, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+
+	returnedSnapshot := snapshotWrapper{}
+
+	res, err := s.client.post(ctx, methodSnapshotCreate, payload, &returnedSnapshot)
+	if err != nil {
+		return Snapshot{}, res, err
+	}
+
+	return returnedSnapshot.Snapshot, res, nil
+}
+
+// Delete deletes a snapshot.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/snapshot.html#snapshot-remove
+func (s *SnapshotService) Delete(ctx context.Context, domainID, snapshotID string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("snapshot_id", snapshotID)
+
+	return s.client.post(ctx, methodSnapshotRemove, payload, nil)
+}
+
+// Rollback replaces the records of a domain with the records of a snapshot.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/snapshot.html#snapshot-rollback
+func (s *SnapshotService) Rollback(ctx context.Context, domainID, snapshotID string) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("snapshot_id", snapshotID)
+
+	return s.client.post(ctx, methodSnapshotRollback, payload, nil)
+}
+
+// SetPeriod sets the period of the automatic snapshots of a domain.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/snapshot.html#snapshot-config
+func (s *SnapshotService) SetPeriod(ctx context.Context, domainID string, period SnapshotPeriod) (*Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("period", string(period))
+
+	return s.client.post(ctx, methodSnapshotConfig, payload, nil)
+}
+
+// Download fetches the records saved in a snapshot.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/snapshot.html#snapshot-download
+func (s *SnapshotService) Download(ctx context.Context, domainID, snapshotID string) ([]SnapshotRecord, *Response, error) {
+	payload := s.client.CommonParams.toPayLoad()
+	setDomainParam(payload, domainID)
+	payload.Set("snapshot_id", snapshotID)
+
+	returnedRecords := snapshotDownloadWrapper{}
+
+	res, err := s.client.post(ctx, methodSnapshotDownload, payload, &returnedRecords)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return returnedRecords.Records, res, nil
+}
+
+// Diff compares a snapshot with the current records of a domain.
+// The records are compared on their name, type, line, value, TTL and, for MX records, priority.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/snapshot.html#snapshot-download
+// - https://www.dnspod.cn/docs/records.html#record-list
+func (s *SnapshotService) Diff(ctx context.Context, domainID, snapshotID string) (SnapshotDiff, *Response, error) {
+	saved, res, err := s.Download(ctx, domainID, snapshotID)
+	if err != nil {
+		return SnapshotDiff{}, res, err
+	}
+
+	current, res, err := s.client.Records.ListAll(ctx, domainID, RecordListOptions{})
+	if err != nil {
+		return SnapshotDiff{}, res, err
+	}
+
+	records := make([]SnapshotRecord, 0, len(current.Records))
+	for _, record := range current.Records {
+		records = append(records, SnapshotRecord{
+			Name:  record.Name,
+			Type:  record.Type,
+			Line:  record.Line,
+			Value: record.Value,
+			TTL:   record.TTL,
+			MX:    record.MX,
+		})
+	}
+
+	return diffSnapshotRecords(saved, records), res, nil
+}
+
+// diffSnapshotRecords returns the records added to and removed from the snapshot, in a stable order.
+func diffSnapshotRecords(saved, current []SnapshotRecord) SnapshotDiff {
+	counts := make(map[string]int, len(saved))
+	for _, record := range saved {
+		counts[record.key()]++
+	}
+
+	diff := SnapshotDiff{}
+
+	for _, record := range current {
+		key := record.key()
+		if counts[key] > 0 {
+			counts[key]--
+			continue
+		}
+
+		diff.Added = append(diff.Added, record)
+	}
+
+	for _, record := range saved {
+		key := record.key()
+		if counts[key] > 0 {
+			counts[key]--
+			diff.Removed = append(diff.Removed, record)
+		}
+	}
+
+	sortSnapshotRecords(diff.Added)
+	sortSnapshotRecords(diff.Removed)
+
+	return diff
+}
+
+func sortSnapshotRecords(records []SnapshotRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].key() < records[j].key()
+	})
+}
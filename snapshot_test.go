@@ -0,0 +1,132 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotService_List(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Snapshot.List", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain") != "example.com" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"snapshots": [
+				{"id": "1001", "domain": "example.com", "created_on": "2026-10-17 10:00:00", "record_count": "3"}
+			]}`)
+	})
+
+	snapshots, _, err := client.Snapshot.List(context.Background(), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Snapshot{{ID: "1001", Domain: "example.com", CreatedOn: "2026-10-17 10:00:00", RecordCount: "3"}}
+	if !reflect.DeepEqual(snapshots, want) {
+		t.Errorf("got %+v, want %+v", snapshots, want)
+	}
+}
+
+func TestSnapshotService_Create(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Snapshot.Create", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain_id") != "2059079" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"snapshot": {"id": "1002", "domain": "example.com", "created_on": "2026-10-17 11:00:00", "record_count": "4"}
+		}`)
+	})
+
+	snapshot, _, err := client.Snapshot.Create(context.Background(), "2059079")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Snapshot{ID: "1002", Domain: "example.com", CreatedOn: "2026-10-17 11:00:00", RecordCount: "4"}
+	if !reflect.DeepEqual(snapshot, want) {
+		t.Errorf("got %+v, want %+v", snapshot, want)
+	}
+}
+
+func TestSnapshotService_Rollback(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Snapshot.Rollback", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain_id") != "2059079" || r.FormValue("snapshot_id") != "1001" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""}}`)
+	})
+
+	_, err := client.Snapshot.Rollback(context.Background(), "2059079", "1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshotService_Diff(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Snapshot.Download", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("domain") != "example.com" || r.FormValue("snapshot_id") != "1001" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"records": [
+				{"sub_domain": "www", "record_type": "A", "record_line": "默认", "value": "1.1.1.1", "ttl": "600"},
+				{"sub_domain": "@", "record_type": "MX", "record_line": "默认", "value": "mx.example.com.", "ttl": "600", "mx": "10"},
+				{"sub_domain": "api", "record_type": "A", "record_line": "默认", "value": "3.3.3.3", "ttl": "600"}
+			]}`)
+	})
+
+	mux.HandleFunc("/Record.List", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"domain": {"id": "2059079", "name": "example.com"},
+			"info": {"sub_domains": "3", "record_total": "3", "records_num": "3"},
+			"records": [
+				{"id": "1", "name": "www", "type": "A", "line": "默认", "value": "1.1.1.1", "ttl": "600", "mx": "0"},
+				{"id": "2", "name": "@", "type": "MX", "line": "默认", "value": "mx.example.com.", "ttl": "600", "mx": "10"},
+				{"id": "3", "name": "api", "type": "A", "line": "默认", "value": "4.4.4.4", "ttl": "600", "mx": "0"}
+			]}`)
+	})
+
+	diff, _, err := client.Snapshot.Diff(context.Background(), "example.com", "1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := SnapshotDiff{
+		Added:   []SnapshotRecord{{Name: "api", Type: RecordTypeA, Line: "默认", Value: "4.4.4.4", TTL: "600", MX: "0"}},
+		Removed: []SnapshotRecord{{Name: "api", Type: RecordTypeA, Line: "默认", Value: "3.3.3.3", TTL: "600"}},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("got %+v, want %+v", diff, want)
+	}
+
+	if diff.Empty() {
+		t.Error("got an empty diff")
+	}
+}
@@ -0,0 +1,715 @@
+package dnspod
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tencentCloudBaseURL   = "https://dnspod.tencentcloudapi.com/"
+	tencentCloudService   = "dnspod"
+	tencentCloudVersion   = "2021-03-23"
+	tencentCloudAlgorithm = "TC3-HMAC-SHA256"
+)
+
+const (
+	actionDescribeDomainList = "DescribeDomainList"
+	actionDescribeDomain     = "DescribeDomain"
+	actionCreateDomain       = "CreateDomain"
+	actionDeleteDomain       = "DeleteDomain"
+	actionDescribeRecordList = "DescribeRecordList"
+	actionDescribeRecord     = "DescribeRecord"
+	actionCreateRecord       = "CreateRecord"
+	actionModifyRecord       = "ModifyRecord"
+	actionDeleteRecord       = "DeleteRecord"
+)
+
+// tencentCloudMethods maps the actions to the equivalent methods of the legacy API,
+// so that the limits of the RateLimit, given by legacy method names, apply to both APIs.
+var tencentCloudMethods = map[string]string{
+	actionDescribeDomainList: methodDomainList,
+	actionDescribeDomain:     methodDomainInfo,
+	actionCreateDomain:       methodDomainCreate,
+	actionDeleteDomain:       methodDomainRemove,
+	actionDescribeRecordList: methodRecordList,
+	actionDescribeRecord:     methodRecordInfo,
+	actionCreateRecord:       methodRecordCreate,
+	actionModifyRecord:       methodRecordModify,
+	actionDeleteRecord:       methodRecordRemove,
+}
+
+// tencentCloud is the apiBackend of the Tencent Cloud API 3.0.
+//
+// Tencent Cloud API docs:
+// - https://cloud.tencent.com/document/api/1427/56153
+type tencentCloud struct {
+	client    *Client
+	secretID  string
+	secretKey string
+	service   string // signed service name, "dnspod"
+
+	now func() time.Time
+}
+
+// tencentCloudEnvelope is the envelope of every response of the Tencent Cloud API.
+type tencentCloudEnvelope struct {
+	Response json.RawMessage `json:"Response"`
+}
+
+type tencentCloudStatus struct {
+	Error *struct {
+		Code    string `json:"Code"`
+		Message string `json:"Message"`
+	} `json:"Error"`
+	RequestID string `json:"RequestId"`
+}
+
+// call sends the request of the action, and decodes the content of the response into v.
+func (t *tencentCloud) call(ctx context.Context, action string, request, v interface{}) (*Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.client.retry(ctx, action, func() (*Response, error) {
+		return t.do(ctx, action, body, v)
+	})
+}
+
+// do performs a single attempt of a call, once allowed by the rate limiter.
+func (t *tencentCloud) do(ctx context.Context, action string, body []byte, v interface{}) (*Response, error) {
+	err := t.client.wait(ctx, tencentCloudMethods[action], rateLimitParams(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := t.newRequest(ctx, action, body)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.client.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	response := &Response{Response: res}
+	err = CheckResponse(res)
+	if err != nil {
+		return response, err
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return response, err
+	}
+
+	envelope := tencentCloudEnvelope{}
+	err = json.Unmarshal(data, &envelope)
+	if err != nil {
+		return response, err
+	}
+
+	status := tencentCloudStatus{}
+	err = json.Unmarshal(envelope.Response, &status)
+	if err != nil {
+		return response, err
+	}
+
+	if status.Error != nil {
+		return response, &APIError{
+			Code:      status.Error.Code,
+			Message:   status.Error.Message,
+			Method:    action,
+			RequestID: status.RequestID,
+			Response:  response,
+		}
+	}
+
+	if v != nil {
+		err = json.Unmarshal(envelope.Response, v)
+	}
+
+	return response, err
+}
+
+// newRequest creates the signed request of the action.
+func (t *tencentCloud) newRequest(ctx context.Context, action string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	now := t.now().UTC()
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", t.client.UserAgent)
+	req.Header.Set("X-TC-Action", action)
+	req.Header.Set("X-TC-Version", tencentCloudVersion)
+	req.Header.Set("X-TC-Timestamp", strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set("Authorization", t.authorization(req.URL.Host, req.URL.Path, body, now))
+
+	return req, nil
+}
+
+// endpoint returns the URL of the API: the BaseURL of the client if it was set by WithBaseURL,
+// before or after WithTencentCloud, and dnspod.tencentcloudapi.com otherwise.
+func (t *tencentCloud) endpoint() string {
+	switch t.client.BaseURL {
+	case "", defaultBaseURL, defaultBaseURLInternational:
+		return tencentCloudBaseURL
+	default:
+		return t.client.BaseURL
+	}
+}
+
+// rateLimitParams returns the parameters of a request usable as KeyParam of a MethodRateLimit,
+// named as in the legacy API: domain, domain_id and record_id.
+func rateLimitParams(body []byte) url.Values {
+	request := struct {
+		Domain   string      `json:"Domain"`
+		DomainID json.Number `json:"DomainId"`
+		RecordID json.Number `json:"RecordId"`
+	}{}
+
+	params := url.Values{}
+
+	if json.Unmarshal(body, &request) != nil {
+		return params
+	}
+
+	if request.DomainID != "" {
+		params.Set("domain_id", request.DomainID.String())
+	} else if request.Domain != "" {
+		params.Set("domain", request.Domain)
+	}
+
+	if request.RecordID != "" {
+		params.Set("record_id", request.RecordID.String())
+	}
+
+	return params
+}
+
+// authorization returns the TC3-HMAC-SHA256 signature of a request.
+// Only the content type and the host headers are signed.
+//
+// Tencent Cloud API docs:
+// - https://cloud.tencent.com/document/api/1427/56189
+func (t *tencentCloud) authorization(host, path string, body []byte, now time.Time) string {
+	if path == "" {
+		path = "/"
+	}
+
+	date := now.Format("2006-01-02")
+	scope := date + "/" + t.service + "/tc3_request"
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		path,
+		"", // query string
+		"content-type:application/json; charset=utf-8\nhost:" + host + "\n",
+		"content-type;host",
+		sha256Hex(body),
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		tencentCloudAlgorithm,
+		strconv.FormatInt(now.Unix(), 10),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	secretDate := hmacSHA256([]byte("TC3"+t.secretKey), date)
+	secretService := hmacSHA256(secretDate, t.service)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+
+	return fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=content-type;host, Signature=%s",
+		tencentCloudAlgorithm, t.secretID, scope, signature)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+// tencentCloudDomain identifies a domain by its name or its id.
+type tencentCloudDomain struct {
+	// Domain is required by the API, but ignored when DomainId is set.
+	Domain   string      `json:"Domain"`
+	DomainID json.Number `json:"DomainId,omitempty"`
+}
+
+func newTencentCloudDomain(domainID string) tencentCloudDomain {
+	if strings.Contains(domainID, ".") {
+		return tencentCloudDomain{Domain: domainID}
+	}
+
+	return tencentCloudDomain{Domain: domainID, DomainID: json.Number(domainID)}
+}
+
+type tencentCloudDomainListRequest struct {
+	Type    string      `json:"Type,omitempty"`
+	Offset  int         `json:"Offset"`
+	Limit   int         `json:"Limit"`
+	GroupID json.Number `json:"GroupId,omitempty"`
+	Keyword string      `json:"Keyword,omitempty"`
+}
+
+type tencentCloudDomainListResponse struct {
+	DomainCountInfo struct {
+		DomainTotal   json.Number `json:"DomainTotal"`
+		AllTotal      json.Number `json:"AllTotal"`
+		MineTotal     json.Number `json:"MineTotal"`
+		ShareTotal    json.Number `json:"ShareTotal"`
+		VipTotal      json.Number `json:"VipTotal"`
+		IsMarkTotal   json.Number `json:"IsMarkTotal"`
+		PauseTotal    json.Number `json:"PauseTotal"`
+		ErrorTotal    json.Number `json:"ErrorTotal"`
+		LockTotal     json.Number `json:"LockTotal"`
+		SpamTotal     json.Number `json:"SpamTotal"`
+		VipExpire     json.Number `json:"VipExpire"`
+		ShareOutTotal json.Number `json:"ShareOutTotal"`
+	} `json:"DomainCountInfo"`
+	DomainList []struct {
+		DomainID         json.Number `json:"DomainId"`
+		Name             string      `json:"Name"`
+		Status           string      `json:"Status"`
+		TTL              json.Number `json:"TTL"`
+		CNAMESpeedup     string      `json:"CNAMESpeedup"`
+		Grade            string      `json:"Grade"`
+		GroupID          json.Number `json:"GroupId"`
+		SearchEnginePush string      `json:"SearchEnginePush"`
+		Remark           string      `json:"Remark"`
+		Punycode         string      `json:"Punycode"`
+		EffectiveDNS     []string    `json:"EffectiveDNS"`
+		GradeTitle       string      `json:"GradeTitle"`
+		IsVip            string      `json:"IsVip"`
+		RecordCount      json.Number `json:"RecordCount"`
+		CreatedOn        string      `json:"CreatedOn"`
+		UpdatedOn        string      `json:"UpdatedOn"`
+		Owner            string      `json:"Owner"`
+	} `json:"DomainList"`
+}
+
+func (t *tencentCloud) ListDomains(ctx context.Context, opts DomainListOptions) (DomainPage, *Response, error) {
+	request := tencentCloudDomainListRequest{
+		Type:    strings.ToUpper(opts.Type),
+		Offset:  opts.Offset,
+		Limit:   opts.Length,
+		GroupID: json.Number(opts.GroupID),
+		Keyword: opts.Keyword,
+	}
+
+	returned := tencentCloudDomainListResponse{}
+
+	res, err := t.call(ctx, actionDescribeDomainList, request, &returned)
+	if err != nil {
+		return DomainPage{}, res, err
+	}
+
+	count := returned.DomainCountInfo
+	page := DomainPage{
+		Info: DomainInfo{
+			DomainTotal:   count.DomainTotal,
+			AllTotal:      count.AllTotal,
+			MineTotal:     count.MineTotal,
+			ShareTotal:    count.ShareTotal,
+			VipTotal:      count.VipTotal,
+			IsMarkTotal:   count.IsMarkTotal,
+			PauseTotal:    count.PauseTotal,
+			ErrorTotal:    count.ErrorTotal,
+			LockTotal:     count.LockTotal,
+			SpamTotal:     count.SpamTotal,
+			VipExpire:     count.VipExpire,
+			ShareOutTotal: count.ShareOutTotal,
+		},
+		Domains: make([]Domain, 0, len(returned.DomainList)),
+	}
+
+	for _, d := range returned.DomainList {
+		page.Domains = append(page.Domains, Domain{
+			ID:               d.DomainID,
+			Name:             d.Name,
+			PunyCode:         d.Punycode,
+			Grade:            d.Grade,
+			GradeTitle:       d.GradeTitle,
+			Status:           strings.ToLower(d.Status),
+			Records:          d.RecordCount.String(),
+			GroupID:          d.GroupID,
+			Remark:           d.Remark,
+			IsVIP:            strings.ToLower(d.IsVip),
+			SearchenginePush: strings.ToLower(d.SearchEnginePush),
+			CreatedOn:        d.CreatedOn,
+			UpdatedOn:        d.UpdatedOn,
+			TTL:              d.TTL,
+			CNameSpeedUp:     strings.ToLower(d.CNAMESpeedup),
+			Owner:            d.Owner,
+			NameServer:       d.EffectiveDNS,
+		})
+	}
+
+	return page, res, nil
+}
+
+type tencentCloudDomainResponse struct {
+	DomainInfo struct {
+		DomainID         json.Number `json:"DomainId"`
+		Domain           string      `json:"Domain"`
+		Status           string      `json:"Status"`
+		Grade            string      `json:"Grade"`
+		GradeTitle       string      `json:"GradeTitle"`
+		GroupID          json.Number `json:"GroupId"`
+		IsMark           string      `json:"IsMark"`
+		TTL              json.Number `json:"TTL"`
+		CnameSpeedup     string      `json:"CnameSpeedup"`
+		Remark           string      `json:"Remark"`
+		Punycode         string      `json:"Punycode"`
+		DnspodNsList     []string    `json:"DnspodNsList"`
+		UserID           json.Number `json:"UserId"`
+		IsVip            string      `json:"IsVip"`
+		SearchEnginePush string      `json:"SearchEnginePush"`
+		Owner            string      `json:"Owner"`
+		RecordCount      json.Number `json:"RecordCount"`
+		CreatedOn        string      `json:"CreatedOn"`
+		UpdatedOn        string      `json:"UpdatedOn"`
+	} `json:"DomainInfo"`
+}
+
+func (t *tencentCloud) GetDomain(ctx context.Context, domainID string) (Domain, *Response, error) {
+	returned := tencentCloudDomainResponse{}
+
+	res, err := t.call(ctx, actionDescribeDomain, newTencentCloudDomain(domainID), &returned)
+	if err != nil {
+		return Domain{}, res, err
+	}
+
+	d := returned.DomainInfo
+
+	return Domain{
+		ID:               d.DomainID,
+		Name:             d.Domain,
+		PunyCode:         d.Punycode,
+		Grade:            d.Grade,
+		GradeTitle:       d.GradeTitle,
+		Status:           strings.ToLower(d.Status),
+		Records:          d.RecordCount.String(),
+		GroupID:          d.GroupID,
+		IsMark:           strings.ToLower(d.IsMark),
+		Remark:           d.Remark,
+		IsVIP:            strings.ToLower(d.IsVip),
+		SearchenginePush: strings.ToLower(d.SearchEnginePush),
+		UserID:           d.UserID.String(),
+		CreatedOn:        d.CreatedOn,
+		UpdatedOn:        d.UpdatedOn,
+		TTL:              d.TTL,
+		CNameSpeedUp:     strings.ToLower(d.CnameSpeedup),
+		Owner:            d.Owner,
+		NameServer:       d.DnspodNsList,
+	}, res, nil
+}
+
+type tencentCloudCreateDomainRequest struct {
+	Domain  string      `json:"Domain"`
+	GroupID json.Number `json:"GroupId,omitempty"`
+	IsMark  string      `json:"IsMark,omitempty"`
+}
+
+type tencentCloudCreateDomainResponse struct {
+	DomainInfo struct {
+		ID          json.Number `json:"Id"`
+		Domain      string      `json:"Domain"`
+		Punycode    string      `json:"Punycode"`
+		GradeNsList []string    `json:"GradeNsList"`
+	} `json:"DomainInfo"`
+}
+
+func (t *tencentCloud) CreateDomain(ctx context.Context, domainAttributes Domain) (DomainCreateResp, *Response, error) {
+	request := tencentCloudCreateDomainRequest{
+		Domain:  domainAttributes.Name,
+		GroupID: domainAttributes.GroupID,
+		IsMark:  domainAttributes.IsMark,
+	}
+
+	returned := tencentCloudCreateDomainResponse{}
+
+	res, err := t.call(ctx, actionCreateDomain, request, &returned)
+	if err != nil {
+		return DomainCreateResp{}, res, err
+	}
+
+	return DomainCreateResp{
+		Id:       returned.DomainInfo.ID.String(),
+		Punycode: returned.DomainInfo.Punycode,
+		Domain:   returned.DomainInfo.Domain,
+		GradeNs:  returned.DomainInfo.GradeNsList,
+	}, res, nil
+}
+
+func (t *tencentCloud) DeleteDomain(ctx context.Context, domainID string) (*Response, error) {
+	return t.call(ctx, actionDeleteDomain, newTencentCloudDomain(domainID), nil)
+}
+
+type tencentCloudRecordListRequest struct {
+	tencentCloudDomain
+
+	Subdomain    string     `json:"Subdomain,omitempty"`
+	RecordType   RecordType `json:"RecordType,omitempty"`
+	RecordLine   string     `json:"RecordLine,omitempty"`
+	RecordLineID string     `json:"RecordLineId,omitempty"`
+	Keyword      string     `json:"Keyword,omitempty"`
+	Offset       int        `json:"Offset"`
+	Limit        int        `json:"Limit"`
+}
+
+type tencentCloudRecordListResponse struct {
+	RecordCountInfo struct {
+		SubdomainCount json.Number `json:"SubdomainCount"`
+		ListCount      json.Number `json:"ListCount"`
+		TotalCount     json.Number `json:"TotalCount"`
+	} `json:"RecordCountInfo"`
+	RecordList []struct {
+		RecordID      json.Number `json:"RecordId"`
+		Value         string      `json:"Value"`
+		Status        string      `json:"Status"`
+		UpdatedOn     string      `json:"UpdatedOn"`
+		Name          string      `json:"Name"`
+		Line          string      `json:"Line"`
+		LineID        string      `json:"LineId"`
+		Type          RecordType  `json:"Type"`
+		Weight        *int        `json:"Weight"`
+		MonitorStatus string      `json:"MonitorStatus"`
+		Remark        string      `json:"Remark"`
+		TTL           json.Number `json:"TTL"`
+		MX            json.Number `json:"MX"`
+	} `json:"RecordList"`
+}
+
+func (t *tencentCloud) ListRecords(ctx context.Context, domainID string, opts RecordListOptions) (*DomainWithRecords, *Response, error) {
+	if opts.RecordID != "" {
+		return nil, nil, fmt.Errorf("%w: %s with a record id", ErrUnsupported, actionDescribeRecordList)
+	}
+
+	request := tencentCloudRecordListRequest{
+		tencentCloudDomain: newTencentCloudDomain(domainID),
+		Subdomain:          opts.SubDomain,
+		RecordType:         opts.RecordType,
+		RecordLine:         opts.RecordLine,
+		RecordLineID:       opts.RecordLineID,
+		Keyword:            opts.Keyword,
+		Offset:             opts.Offset,
+		Limit:              opts.Length,
+	}
+
+	returned := tencentCloudRecordListResponse{}
+
+	res, err := t.call(ctx, actionDescribeRecordList, request, &returned)
+	if err != nil {
+		return nil, res, err
+	}
+
+	count := returned.RecordCountInfo
+	page := &DomainWithRecords{
+		Info: DomainInfo{
+			SubDomains:  count.SubdomainCount,
+			RecordTotal: count.TotalCount,
+			RecordsNum:  count.TotalCount,
+		},
+		Records: make([]Record, 0, len(returned.RecordList)),
+	}
+
+	for _, r := range returned.RecordList {
+		page.Records = append(page.Records, Record{
+			ID:            r.RecordID.String(),
+			Name:          r.Name,
+			Line:          r.Line,
+			LineID:        r.LineID,
+			Type:          r.Type,
+			TTL:           r.TTL.String(),
+			Value:         r.Value,
+			MX:            r.MX.String(),
+			Enabled:       tencentCloudEnabled(r.Status),
+			Status:        strings.ToLower(r.Status),
+			MonitorStatus: r.MonitorStatus,
+			Remark:        r.Remark,
+			UpdateOn:      r.UpdatedOn,
+			Weight:        r.Weight,
+		})
+	}
+
+	return page, res, nil
+}
+
+type tencentCloudRecordRequest struct {
+	tencentCloudDomain
+
+	RecordID json.Number `json:"RecordId"`
+}
+
+type tencentCloudRecordResponse struct {
+	RecordInfo struct {
+		ID            json.Number `json:"Id"`
+		SubDomain     string      `json:"SubDomain"`
+		RecordType    RecordType  `json:"RecordType"`
+		RecordLine    string      `json:"RecordLine"`
+		RecordLineID  string      `json:"RecordLineId"`
+		Value         string      `json:"Value"`
+		Weight        *int        `json:"Weight"`
+		MX            json.Number `json:"MX"`
+		TTL           json.Number `json:"TTL"`
+		Enabled       json.Number `json:"Enabled"`
+		MonitorStatus string      `json:"MonitorStatus"`
+		Remark        string      `json:"Remark"`
+		UpdatedOn     string      `json:"UpdatedOn"`
+	} `json:"RecordInfo"`
+}
+
+func (t *tencentCloud) GetRecord(ctx context.Context, domainID, recordID string) (Record, *Response, error) {
+	request := tencentCloudRecordRequest{
+		tencentCloudDomain: newTencentCloudDomain(domainID),
+		RecordID:           json.Number(recordID),
+	}
+
+	returned := tencentCloudRecordResponse{}
+
+	res, err := t.call(ctx, actionDescribeRecord, request, &returned)
+	if err != nil {
+		return Record{}, res, err
+	}
+
+	r := returned.RecordInfo
+
+	status := string(RecordStatusDisable)
+	if r.Enabled == "1" {
+		status = string(RecordStatusEnable)
+	}
+
+	return Record{
+		ID:            r.ID.String(),
+		Name:          r.SubDomain,
+		Line:          r.RecordLine,
+		LineID:        r.RecordLineID,
+		Type:          r.RecordType,
+		TTL:           r.TTL.String(),
+		Value:         r.Value,
+		MX:            r.MX.String(),
+		Enabled:       r.Enabled.String(),
+		Status:        status,
+		MonitorStatus: r.MonitorStatus,
+		Remark:        r.Remark,
+		UpdateOn:      r.UpdatedOn,
+		Weight:        r.Weight,
+	}, res, nil
+}
+
+type tencentCloudRecordAttributes struct {
+	tencentCloudDomain
+
+	RecordID     json.Number `json:"RecordId,omitempty"`
+	SubDomain    string      `json:"SubDomain,omitempty"`
+	RecordType   RecordType  `json:"RecordType"`
+	RecordLine   string      `json:"RecordLine"`
+	RecordLineID string      `json:"RecordLineId,omitempty"`
+	Value        string      `json:"Value"`
+	MX           json.Number `json:"MX,omitempty"`
+	TTL          json.Number `json:"TTL,omitempty"`
+	Weight       *int        `json:"Weight,omitempty"`
+	Status       string      `json:"Status,omitempty"`
+}
+
+func newTencentCloudRecordAttributes(domainID string, record Record) tencentCloudRecordAttributes {
+	attributes := tencentCloudRecordAttributes{
+		tencentCloudDomain: newTencentCloudDomain(domainID),
+		SubDomain:          record.Name,
+		RecordType:         record.Type,
+		RecordLine:         record.Line,
+		RecordLineID:       record.LineID,
+		Value:              record.Value,
+		MX:                 json.Number(record.MX),
+		TTL:                json.Number(record.TTL),
+		Weight:             record.Weight,
+		Status:             strings.ToUpper(record.Status),
+	}
+
+	if attributes.RecordLine == "" {
+		// required by the API, but ignored when the line id is set.
+		attributes.RecordLine = "默认"
+	}
+
+	return attributes
+}
+
+type tencentCloudRecordIDResponse struct {
+	RecordID json.Number `json:"RecordId"`
+}
+
+func (t *tencentCloud) CreateRecord(ctx context.Context, domainID string, record Record) (Record, *Response, error) {
+	returned := tencentCloudRecordIDResponse{}
+
+	res, err := t.call(ctx, actionCreateRecord, newTencentCloudRecordAttributes(domainID, record), &returned)
+	if err != nil {
+		return Record{}, res, err
+	}
+
+	record.ID = returned.RecordID.String()
+
+	return record, res, nil
+}
+
+func (t *tencentCloud) UpdateRecord(ctx context.Context, domainID, recordID string, record Record) (RecordModify, *Response, error) {
+	request := newTencentCloudRecordAttributes(domainID, record)
+	request.RecordID = json.Number(recordID)
+
+	returned := tencentCloudRecordIDResponse{}
+
+	res, err := t.call(ctx, actionModifyRecord, request, &returned)
+	if err != nil {
+		return RecordModify{}, res, err
+	}
+
+	return RecordModify{
+		ID:     returned.RecordID,
+		Name:   record.Name,
+		Value:  record.Value,
+		Status: record.Status,
+	}, res, nil
+}
+
+func (t *tencentCloud) DeleteRecord(ctx context.Context, domainID, recordID string) (*Response, error) {
+	request := tencentCloudRecordRequest{
+		tencentCloudDomain: newTencentCloudDomain(domainID),
+		RecordID:           json.Number(recordID),
+	}
+
+	return t.call(ctx, actionDeleteRecord, request, nil)
+}
+
+// tencentCloudEnabled converts a record status of the Tencent Cloud API to the enabled flag of the legacy API.
+func tencentCloudEnabled(status string) string {
+	if strings.EqualFold(status, "ENABLE") {
+		return "1"
+	}
+
+	return "0"
+}
@@ -0,0 +1,232 @@
+package dnspod
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func setupTencentCloudClient() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient(CommonParams{}, WithTencentCloud("AKIDEXAMPLE", "SecretKeyExample"), WithBaseURL(server.URL))
+	client.backend.(*tencentCloud).now = func() time.Time { return time.Unix(1792195200, 0) }
+
+	return client, mux, func() {
+		server.Close()
+	}
+}
+
+// handleAction registers the handler of a Tencent Cloud API action, decoding its JSON request into v.
+func handleAction(mux *http.ServeMux, action string, v interface{}, handler func(w http.ResponseWriter)) {
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-TC-Action") != action || r.Header.Get("X-TC-Version") != tencentCloudVersion {
+			http.Error(w, "unexpected action", http.StatusBadRequest)
+			return
+		}
+
+		if v != nil && json.NewDecoder(r.Body).Decode(v) != nil {
+			http.Error(w, "unexpected body", http.StatusBadRequest)
+			return
+		}
+
+		handler(w)
+	})
+}
+
+// TestTencentCloud_authorization checks the signature of the example of the Tencent Cloud documentation.
+//
+// Tencent Cloud API docs:
+// - https://cloud.tencent.com/document/api/213/30654
+func TestTencentCloud_authorization(t *testing.T) {
+	tc := &tencentCloud{
+		secretID:  "AKIDz8krbsJ5yKBZQpn74WFkmLPx3EXAMPLE",
+		secretKey: "Gu5t9xGARNpq86cd98joQYCN3EXAMPLE",
+		service:   "cvm",
+	}
+
+	body := []byte(`{"Limit": 1, "Filters": [{"Values": ["\u672a\u547d\u540d"], "Name": "instance-name"}]}`)
+
+	got := tc.authorization("cvm.tencentcloudapi.com", "/", body, time.Unix(1551113065, 0).UTC())
+
+	want := "TC3-HMAC-SHA256 Credential=AKIDz8krbsJ5yKBZQpn74WFkmLPx3EXAMPLE/2019-02-25/cvm/tc3_request, SignedHeaders=content-type;host, " +
+		"Signature=72e494ea809ad7a8c8f7a4507b9bddcbaa8e581f516e8da2f66e2c5a96525168"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTencentCloud_DomainsList(t *testing.T) {
+	client, mux, teardown := setupTencentCloudClient()
+	defer teardown()
+
+	request := map[string]interface{}{}
+
+	handleAction(mux, actionDescribeDomainList, &request, func(w http.ResponseWriter) {
+		_, _ = fmt.Fprint(w, `{"Response": {
+			"DomainCountInfo": {"DomainTotal": 1, "AllTotal": 1, "MineTotal": 1},
+			"DomainList": [{
+				"DomainId": 2059079,
+				"Name": "example.com",
+				"Status": "ENABLE",
+				"TTL": 600,
+				"CNAMESpeedup": "DISABLE",
+				"Grade": "DP_FREE",
+				"GroupId": 1,
+				"SearchEnginePush": "NO",
+				"Punycode": "example.com",
+				"EffectiveDNS": ["f1g1ns1.dnspod.net", "f1g1ns2.dnspod.net"],
+				"IsVip": "NO",
+				"RecordCount": 3
+			}],
+			"RequestId": "2f3c9a54-1ee5-4d0b-b2a6-4c8a7b3bb9f8"
+		}}`)
+	})
+
+	domains, _, err := client.Domains.ListWithContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if request["Offset"] != float64(0) || request["Limit"] != float64(defaultDomainPageLength) {
+		t.Errorf("unexpected request %+v", request)
+	}
+
+	want := []Domain{{
+		ID:               "2059079",
+		Name:             "example.com",
+		PunyCode:         "example.com",
+		Grade:            "DP_FREE",
+		Status:           "enable",
+		Records:          "3",
+		GroupID:          "1",
+		IsVIP:            "no",
+		SearchenginePush: "no",
+		TTL:              "600",
+		CNameSpeedUp:     "disable",
+		NameServer:       []string{"f1g1ns1.dnspod.net", "f1g1ns2.dnspod.net"},
+	}}
+	if !reflect.DeepEqual(domains, want) {
+		t.Errorf("got %+v, want %+v", domains, want)
+	}
+}
+
+func TestTencentCloud_RecordsCreate(t *testing.T) {
+	client, mux, teardown := setupTencentCloudClient()
+	defer teardown()
+
+	request := map[string]interface{}{}
+
+	handleAction(mux, actionCreateRecord, &request, func(w http.ResponseWriter) {
+		_, _ = fmt.Fprint(w, `{"Response": {"RecordId": 16894439, "RequestId": "2f3c9a54-1ee5-4d0b-b2a6-4c8a7b3bb9f8"}}`)
+	})
+
+	record := Record{Name: "www", Type: RecordTypeA, Line: "default", Value: "1.1.1.1", TTL: "600"}
+
+	created, _, err := client.Records.CreateWithContext(context.Background(), "example.com", record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantRequest := map[string]interface{}{
+		"Domain":     "example.com",
+		"SubDomain":  "www",
+		"RecordType": "A",
+		"RecordLine": "默认",
+		"Value":      "1.1.1.1",
+		"TTL":        float64(600),
+	}
+	if !reflect.DeepEqual(request, wantRequest) {
+		t.Errorf("got request %+v, want %+v", request, wantRequest)
+	}
+
+	want := Record{ID: "16894439", Name: "www", Type: RecordTypeA, Line: "默认", Value: "1.1.1.1", TTL: "600"}
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("got %+v, want %+v", created, want)
+	}
+}
+
+func TestTencentCloud_RecordsGet_error(t *testing.T) {
+	client, mux, teardown := setupTencentCloudClient()
+	defer teardown()
+
+	handleAction(mux, actionDescribeRecord, nil, func(w http.ResponseWriter) {
+		_, _ = fmt.Fprint(w, `{"Response": {
+			"Error": {"Code": "InvalidParameter.RecordIdInvalid", "Message": "记录编号错误。"},
+			"RequestId": "2f3c9a54-1ee5-4d0b-b2a6-4c8a7b3bb9f8"
+		}}`)
+	})
+
+	_, _, err := client.Records.GetWithContext(context.Background(), "2059079", "1")
+
+	if !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("got %v, want %v", err, ErrRecordNotFound)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != "2f3c9a54-1ee5-4d0b-b2a6-4c8a7b3bb9f8" {
+		t.Errorf("got %#v, want an *APIError with the request id", err)
+	}
+}
+
+func TestTencentCloud_unsupported(t *testing.T) {
+	client, _, teardown := setupTencentCloudClient()
+	defer teardown()
+
+	_, err := client.Domains.SetStatus(context.Background(), "example.com", DomainStatusDisable)
+
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("got %v, want %v", err, ErrUnsupported)
+	}
+}
+
+func TestTencentCloud_endpoint(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		options []Option
+		want    string
+	}{
+		{desc: "default", options: []Option{WithTencentCloud("id", "key")}, want: tencentCloudBaseURL},
+		{desc: "base URL before", options: []Option{WithBaseURL("https://proxy.example.com"), WithTencentCloud("id", "key")}, want: "https://proxy.example.com/"},
+		{desc: "base URL after", options: []Option{WithTencentCloud("id", "key"), WithBaseURL("https://proxy.example.com")}, want: "https://proxy.example.com/"},
+	}
+
+	for _, test := range testCases {
+		client := NewClient(CommonParams{}, test.options...)
+
+		if got := client.backend.(*tencentCloud).endpoint(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestTencentCloud_rateLimit(t *testing.T) {
+	client, mux, teardown := setupTencentCloudClient()
+	defer teardown()
+
+	WithRateLimit(RateLimit{
+		Methods: map[string]MethodRateLimit{
+			methodRecordModify: {Rate: 10, KeyParam: "record_id"},
+		},
+	})(client)
+
+	handleAction(mux, actionModifyRecord, nil, func(w http.ResponseWriter) {
+		_, _ = fmt.Fprint(w, `{"Response": {"RecordId": 16894439, "RequestId": "2f3c9a54-1ee5-4d0b-b2a6-4c8a7b3bb9f8"}}`)
+	})
+
+	_, _, err := client.Records.UpdateWithContext(context.Background(), "2059079", "16894439", Record{Name: "www", Type: RecordTypeA, Value: "1.1.1.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := client.limiter.buckets[methodRecordModify+"/16894439"]; !ok {
+		t.Errorf("got buckets %v, want a bucket of the record", client.limiter.buckets)
+	}
+}
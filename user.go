@@ -1,9 +1,18 @@
 package dnspod
 
-import "fmt"
+import (
+	"context"
+	"strconv"
+)
 
 const (
-	methodUserDetail = "User.Detail"
+	methodUserDetail          = "User.Detail"
+	methodUserModify          = "User.Modify"
+	methodUserPasswdModify    = "Userpasswd.Modify"
+	methodUserEmailModify     = "Useremail.Modify"
+	methodTelephoneVerifyCode = "Telephoneverify.Code"
+	methodUserLog             = "User.Log"
+	methodInfoVersion         = "Info.Version"
 )
 
 type User struct {
@@ -36,28 +45,171 @@ type UserInfo struct {
 	Agent Agent `json:"agent"`
 }
 
+// UserModify is the modification of the profile of the account.
+// Empty fields are left unchanged.
+type UserModify struct {
+	RealName  string
+	Nick      string
+	Telephone string
+	IM        string
+}
+
+// UserLogOptions is the pagination of the account log.
+type UserLogOptions struct {
+	Offset int
+
+	// Length is the number of entries to fetch.
+	// Defaults to the API default (500).
+	Length int
+}
+
+// UserLogEntry is an entry of the operation log of the account.
+// The account log has the same format as the domain log.
+type UserLogEntry = DomainLogEntry
+
 type userWrapper struct {
-	Status Status   `json:"status"`
-	Info   UserInfo `json:"info"`
+	Info UserInfo `json:"info"`
+}
+
+type userLogWrapper struct {
+	Log []string `json:"log"`
 }
 
 type UserService struct {
 	client *Client
 }
 
+// Profile fetches the profile of the account.
+//
+// Deprecated: use ProfileWithContext. Profile will be removed in the next release.
 func (u *UserService) Profile() (UserInfo, *Response, error) {
+	return u.ProfileWithContext(context.Background())
+}
+
+// ProfileWithContext fetches the profile of the account.
+func (u *UserService) ProfileWithContext(ctx context.Context) (UserInfo, *Response, error) {
 	payload := u.client.CommonParams.toPayLoad()
 
 	returnedUserInfo := userWrapper{}
 
-	res, err := u.client.post(methodUserDetail, payload, &returnedUserInfo)
+	res, err := u.client.post(ctx, methodUserDetail, payload, &returnedUserInfo)
 	if err != nil {
-		return UserInfo{}, nil, err
+		return UserInfo{}, res, err
+	}
+
+	return returnedUserInfo.Info, res, nil
+}
+
+// Modify updates the profile of the account.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/accounts.html#user-modify
+func (u *UserService) Modify(ctx context.Context, modify UserModify) (*Response, error) {
+	payload := u.client.CommonParams.toPayLoad()
+
+	if modify.RealName != "" {
+		payload.Set("real_name", modify.RealName)
 	}
 
-	if returnedUserInfo.Status.Code != "1" {
-		return UserInfo{}, nil, fmt.Errorf("code: %s, message: %s", returnedUserInfo.Status.Code, returnedUserInfo.Status.Message)
+	if modify.Nick != "" {
+		payload.Set("nick", modify.Nick)
 	}
 
-	return returnedUserInfo.Info, res, nil
+	if modify.Telephone != "" {
+		payload.Set("telephone", modify.Telephone)
+	}
+
+	if modify.IM != "" {
+		payload.Set("im", modify.IM)
+	}
+
+	return u.client.post(ctx, methodUserModify, payload, nil)
+}
+
+// ChangePassword changes the password of the account.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/accounts.html#userpasswd-modify
+func (u *UserService) ChangePassword(ctx context.Context, oldPassword, newPassword string) (*Response, error) {
+	payload := u.client.CommonParams.toPayLoad()
+	payload.Set("old_password", oldPassword)
+	payload.Set("new_password", newPassword)
+
+	return u.client.post(ctx, methodUserPasswdModify, payload, nil)
+}
+
+// ChangeEmail changes the email address of the account.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/accounts.html#useremail-modify
+func (u *UserService) ChangeEmail(ctx context.Context, oldEmail, newEmail, password string) (*Response, error) {
+	payload := u.client.CommonParams.toPayLoad()
+	payload.Set("old_email", oldEmail)
+	payload.Set("new_email", newEmail)
+	payload.Set("password", password)
+
+	return u.client.post(ctx, methodUserEmailModify, payload, nil)
+}
+
+// SendTelephoneVerifyCode sends a verification code by SMS to the telephone number.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/accounts.html#telephoneverify-code
+func (u *UserService) SendTelephoneVerifyCode(ctx context.Context, telephone string) (*Response, error) {
+	payload := u.client.CommonParams.toPayLoad()
+	payload.Set("telephone", telephone)
+
+	return u.client.post(ctx, methodTelephoneVerifyCode, payload, nil)
+}
+
+// Log fetches the operation log of the account, most recent entries first.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/accounts.html#user-log
+func (u *UserService) Log(ctx context.Context, opts UserLogOptions) ([]UserLogEntry, *Response, error) {
+	payload := u.client.CommonParams.toPayLoad()
+
+	if opts.Offset > 0 {
+		payload.Set("offset", strconv.Itoa(opts.Offset))
+	}
+
+	if opts.Length > 0 {
+		payload.Set("length", strconv.Itoa(opts.Length))
+	}
+
+	returnedLog := userLogWrapper{}
+
+	res, err := u.client.post(ctx, methodUserLog, payload, &returnedLog)
+	if err != nil {
+		return nil, res, err
+	}
+
+	entries := make([]UserLogEntry, 0, len(returnedLog.Log))
+	for _, line := range returnedLog.Log {
+		entries = append(entries, parseLogLine(line))
+	}
+
+	return entries, res, nil
+}
+
+// Version fetches the version of the API.
+// It is a cheap way to check the connectivity and the credentials of the client.
+//
+// DNSPod API docs:
+// - https://www.dnspod.cn/docs/info.html#info-version
+func (u *UserService) Version(ctx context.Context) (string, *Response, error) {
+	payload := u.client.CommonParams.toPayLoad()
+
+	returnedStatus := statusEnvelope{}
+
+	res, err := u.client.post(ctx, methodInfoVersion, payload, &returnedStatus)
+	if err != nil {
+		return "", res, err
+	}
+
+	if returnedStatus.Status == nil {
+		return "", res, nil
+	}
+
+	return returnedStatus.Status.Message, res, nil
 }
@@ -0,0 +1,81 @@
+package dnspod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUserService_Profile(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/User.Detail", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":""},"info":{"user":{"id":"1","nick":"example"}}}`)
+	})
+
+	info, _, err := client.User.Profile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := UserInfo{User: User{Id: "1", Nick: "example"}}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("got %+v, want %+v", info, want)
+	}
+}
+
+func TestUserService_Log(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/User.Log", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("offset") != "500" || r.FormValue("length") != "100" {
+			http.Error(w, "unexpected parameters", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{
+			"status": {"code":"1","message":""},
+			"log": ["2026-10-17 10:55:34: (127.0.0.1) user@example.com 修改密码"]
+		}`)
+	})
+
+	entries, _, err := client.User.Log(context.Background(), UserLogOptions{Offset: 500, Length: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []UserLogEntry{{
+		Time:     time.Date(2026, 10, 17, 10, 55, 34, 0, logLocation),
+		IP:       "127.0.0.1",
+		Operator: "user@example.com",
+		Action:   "修改密码",
+		Message:  "修改密码",
+		Raw:      "2026-10-17 10:55:34: (127.0.0.1) user@example.com 修改密码",
+	}}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("got %+v, want %+v", entries, want)
+	}
+}
+
+func TestUserService_Version(t *testing.T) {
+	client, mux, teardown := setupClient()
+	defer teardown()
+
+	mux.HandleFunc("/Info.Version", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"status": {"code":"1","message":"4.6","created_at":"2026-10-17 10:55:34"}}`)
+	})
+
+	version, _, err := client.User.Version(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version != "4.6" {
+		t.Errorf("got %q, want %q", version, "4.6")
+	}
+}